@@ -0,0 +1,349 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2023 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package backfill provides a fetcher downloading historical block ranges concurrently.
+//
+// The fetcher splits a height range into windows which are fetched by a bounded number
+// of workers over any access.Client, so it works the same with the gRPC and HTTP clients.
+// Blocks are handed to the caller strictly ordered by height, failed windows are retried
+// and progress is reported after every window:
+//
+//	fetcher := backfill.NewFetcher(client, backfill.WithWorkers(8), backfill.WithCollections())
+//	err := fetcher.Fetch(ctx, 1_000_000, 2_000_000, func(item *backfill.Item) error {
+//	    return index(item)
+//	})
+package backfill
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/access"
+)
+
+const (
+	// DefaultWorkers is the default number of windows fetched concurrently.
+	DefaultWorkers = 4
+	// DefaultWindowSize is the default number of heights in a window.
+	DefaultWindowSize = 50
+	// DefaultRetries is the default number of times a failed window is retried.
+	DefaultRetries = 3
+	// DefaultRetryDelay is the default delay before retrying a failed window, doubled on every attempt.
+	DefaultRetryDelay = 500 * time.Millisecond
+)
+
+// Item is a block fetched by the fetcher, with the optional related entities.
+type Item struct {
+	Block *flow.Block
+	// Collections are the collections guaranteed in the block, in the order of the guarantees.
+	Collections []*flow.Collection
+	// Transactions are the transactions of the block.
+	Transactions []*flow.Transaction
+	// Results are the transaction results of the block.
+	Results []*flow.TransactionResult
+}
+
+// Progress describes the state of a fetch.
+type Progress struct {
+	// Start and End are the heights of the fetched range.
+	Start uint64
+	End   uint64
+	// Height is the last height handed to the caller.
+	Height uint64
+	// Done is the number of heights handed to the caller.
+	Done uint64
+	// Total is the number of heights in the range.
+	Total uint64
+	// Retries is the number of window retries so far.
+	Retries uint64
+	// Elapsed is the time since the fetch started.
+	Elapsed time.Duration
+}
+
+// Option configures a Fetcher.
+type Option func(*Fetcher)
+
+// WithWorkers sets the number of windows fetched concurrently.
+func WithWorkers(workers int) Option {
+	return func(f *Fetcher) {
+		if workers > 0 {
+			f.workers = workers
+		}
+	}
+}
+
+// WithWindowSize sets the number of heights fetched by a worker at once.
+func WithWindowSize(size uint64) Option {
+	return func(f *Fetcher) {
+		if size > 0 {
+			f.windowSize = size
+		}
+	}
+}
+
+// WithRetries sets the number of times a failed window is retried, and the delay before the first retry.
+func WithRetries(retries int, delay time.Duration) Option {
+	return func(f *Fetcher) {
+		f.retries = retries
+		f.retryDelay = delay
+	}
+}
+
+// WithProgress sets a function called with the progress every time a window is handed to the caller.
+func WithProgress(progress func(Progress)) Option {
+	return func(f *Fetcher) {
+		f.progress = progress
+	}
+}
+
+// WithCollections fetches the collections guaranteed in every block.
+func WithCollections() Option {
+	return func(f *Fetcher) {
+		f.collections = true
+	}
+}
+
+// WithTransactions fetches the transactions of every block.
+func WithTransactions() Option {
+	return func(f *Fetcher) {
+		f.transactions = true
+	}
+}
+
+// WithResults fetches the transaction results of every block.
+func WithResults() Option {
+	return func(f *Fetcher) {
+		f.results = true
+	}
+}
+
+// A WindowError is returned when a window could not be fetched after all retries.
+type WindowError struct {
+	Start uint64
+	End   uint64
+	Err   error
+}
+
+func (e WindowError) Error() string {
+	return fmt.Sprintf("failed to fetch heights %d to %d: %s", e.Start, e.End, e.Err)
+}
+
+func (e WindowError) Unwrap() error {
+	return e.Err
+}
+
+// Fetcher downloads block ranges concurrently.
+type Fetcher struct {
+	client       access.Client
+	workers      int
+	windowSize   uint64
+	retries      int
+	retryDelay   time.Duration
+	progress     func(Progress)
+	collections  bool
+	transactions bool
+	results      bool
+}
+
+// NewFetcher creates a fetcher using the provided client.
+func NewFetcher(client access.Client, opts ...Option) *Fetcher {
+	f := &Fetcher{
+		client:     client,
+		workers:    DefaultWorkers,
+		windowSize: DefaultWindowSize,
+		retries:    DefaultRetries,
+		retryDelay: DefaultRetryDelay,
+	}
+	for _, opt := range opts {
+		opt(f)
+	}
+	return f
+}
+
+// window is a range of heights fetched by a single worker.
+type window struct {
+	start uint64
+	end   uint64
+	done  chan struct{}
+	items []*Item
+	err   error
+	tries int
+}
+
+// Fetch downloads the blocks from start to end (inclusive) and calls handle for every block, ordered by height.
+//
+// Fetching stops at the first error returned by handle or at the first window failing after all retries.
+func (f *Fetcher) Fetch(ctx context.Context, start uint64, end uint64, handle func(*Item) error) error {
+	if start > end {
+		return fmt.Errorf("start height %d is greater than end height %d", start, end)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	jobs := make(chan *window)
+	// pending bounds the number of windows fetched ahead of the caller and keeps them ordered
+	pending := make(chan *window, f.workers)
+
+	for i := 0; i < f.workers; i++ {
+		go func() {
+			for w := range jobs {
+				f.fetchWindow(ctx, w)
+				close(w.done)
+			}
+		}()
+	}
+
+	go func() {
+		defer close(jobs)
+		defer close(pending)
+
+		for from := start; from <= end; from += f.windowSize {
+			to := from + f.windowSize - 1
+			if to > end || to < from {
+				to = end
+			}
+
+			w := &window{start: from, end: to, done: make(chan struct{})}
+			select {
+			case pending <- w:
+			case <-ctx.Done():
+				return
+			}
+			select {
+			case jobs <- w:
+			case <-ctx.Done():
+				return
+			}
+
+			if to == end {
+				return
+			}
+		}
+	}()
+
+	progress := Progress{
+		Start: start,
+		End:   end,
+		Total: end - start + 1,
+	}
+	began := time.Now()
+
+	for w := range pending {
+		select {
+		case <-w.done:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+
+		progress.Retries += uint64(w.tries)
+		if w.err != nil {
+			return WindowError{Start: w.start, End: w.end, Err: w.err}
+		}
+
+		for _, item := range w.items {
+			if err := handle(item); err != nil {
+				return err
+			}
+		}
+
+		if f.progress != nil {
+			progress.Height = w.end
+			progress.Done += w.end - w.start + 1
+			progress.Elapsed = time.Since(began)
+			f.progress(progress)
+		}
+	}
+
+	return ctx.Err()
+}
+
+// fetchWindow fetches all the heights of the window, retrying the whole window on failure.
+func (f *Fetcher) fetchWindow(ctx context.Context, w *window) {
+	delay := f.retryDelay
+
+	for {
+		w.items, w.err = f.fetchHeights(ctx, w.start, w.end)
+		if w.err == nil || w.tries >= f.retries || errors.Is(w.err, context.Canceled) {
+			return
+		}
+
+		w.tries++
+		select {
+		case <-time.After(delay):
+			delay *= 2
+		case <-ctx.Done():
+			w.err = ctx.Err()
+			return
+		}
+	}
+}
+
+func (f *Fetcher) fetchHeights(ctx context.Context, start uint64, end uint64) ([]*Item, error) {
+	items := make([]*Item, 0, end-start+1)
+
+	for height := start; ; height++ {
+		item, err := f.fetchHeight(ctx, height)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+
+		if height == end {
+			return items, nil
+		}
+	}
+}
+
+func (f *Fetcher) fetchHeight(ctx context.Context, height uint64) (*Item, error) {
+	block, err := f.client.GetBlockByHeight(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+
+	item := &Item{Block: block}
+
+	if f.collections {
+		item.Collections = make([]*flow.Collection, len(block.CollectionGuarantees))
+		for i, guarantee := range block.CollectionGuarantees {
+			item.Collections[i], err = f.client.GetCollection(ctx, guarantee.CollectionID)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if f.transactions {
+		item.Transactions, err = f.client.GetTransactionsByBlockID(ctx, block.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if f.results {
+		item.Results, err = f.client.GetTransactionResultsByBlockID(ctx, block.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return item, nil
+}
@@ -0,0 +1,139 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2023 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package backfill
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/access"
+)
+
+// fakeClient implements the methods used by the tests, any other method panics.
+type fakeClient struct {
+	access.Client
+
+	mu       sync.Mutex
+	failures map[uint64]int
+}
+
+func (f *fakeClient) GetBlockByHeight(_ context.Context, height uint64) (*flow.Block, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.failures[height] > 0 {
+		f.failures[height]--
+		return nil, errors.New("unavailable")
+	}
+
+	return &flow.Block{
+		BlockHeader: flow.BlockHeader{ID: flow.Identifier{byte(height)}, Height: height},
+		BlockPayload: flow.BlockPayload{
+			CollectionGuarantees: []*flow.CollectionGuarantee{{CollectionID: flow.Identifier{byte(height)}}},
+		},
+	}, nil
+}
+
+func (f *fakeClient) GetCollection(_ context.Context, colID flow.Identifier) (*flow.Collection, error) {
+	return &flow.Collection{TransactionIDs: []flow.Identifier{colID}}, nil
+}
+
+func TestFetcher_Ordered(t *testing.T) {
+	var progress []Progress
+	fetcher := NewFetcher(
+		&fakeClient{},
+		WithWorkers(4),
+		WithWindowSize(3),
+		WithCollections(),
+		WithProgress(func(p Progress) { progress = append(progress, p) }),
+	)
+
+	var heights []uint64
+	err := fetcher.Fetch(context.Background(), 10, 30, func(item *Item) error {
+		heights = append(heights, item.Block.Height)
+		require.Len(t, item.Collections, 1)
+		assert.Equal(t, item.Block.CollectionGuarantees[0].CollectionID, item.Collections[0].TransactionIDs[0])
+		return nil
+	})
+	require.NoError(t, err)
+
+	require.Len(t, heights, 21)
+	for i, height := range heights {
+		assert.Equal(t, uint64(10+i), height)
+	}
+
+	require.Len(t, progress, 7)
+	last := progress[len(progress)-1]
+	assert.Equal(t, uint64(30), last.Height)
+	assert.Equal(t, uint64(21), last.Done)
+	assert.Equal(t, uint64(21), last.Total)
+}
+
+func TestFetcher_Retry(t *testing.T) {
+	client := &fakeClient{failures: map[uint64]int{5: 2}}
+	fetcher := NewFetcher(client, WithWindowSize(2), WithRetries(2, 0))
+
+	var progress Progress
+	fetcher.progress = func(p Progress) { progress = p }
+
+	count := 0
+	err := fetcher.Fetch(context.Background(), 0, 9, func(*Item) error {
+		count++
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 10, count)
+	assert.Equal(t, uint64(2), progress.Retries)
+}
+
+func TestFetcher_WindowError(t *testing.T) {
+	client := &fakeClient{failures: map[uint64]int{5: 10}}
+	fetcher := NewFetcher(client, WithWindowSize(2), WithRetries(1, 0))
+
+	count := 0
+	err := fetcher.Fetch(context.Background(), 0, 9, func(*Item) error {
+		count++
+		return nil
+	})
+
+	var windowErr WindowError
+	require.ErrorAs(t, err, &windowErr)
+	assert.Equal(t, uint64(4), windowErr.Start)
+	assert.Equal(t, uint64(5), windowErr.End)
+	assert.Equal(t, 4, count)
+}
+
+func TestFetcher_HandleError(t *testing.T) {
+	fetcher := NewFetcher(&fakeClient{}, WithWindowSize(2))
+	handleErr := errors.New("stop")
+
+	err := fetcher.Fetch(context.Background(), 0, 100, func(item *Item) error {
+		if item.Block.Height == 7 {
+			return handleErr
+		}
+		return nil
+	})
+	assert.ErrorIs(t, err, handleErr)
+}
@@ -0,0 +1,360 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2023 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package cache provides an access client caching immutable entities.
+//
+// Sealed blocks and block headers, collections and the execution results of sealed blocks
+// never change, so indexers repeatedly fetching them can be served from a local store
+// instead of the access node. Queries for the latest block, unsealed blocks and any other
+// query are passed through to the wrapped client.
+//
+// The client is backed by a Store, either the in-memory LRUStore or the on-disk DiskStore
+// which survives restarts, or any other implementation of the Store interface:
+//
+//	store, err := cache.NewLRUStore(10_000)
+//	client, err := cache.NewClient(grpcClient, store)
+package cache
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/access"
+)
+
+// Kind is the kind of entity cached by the client.
+type Kind string
+
+const (
+	KindBlockHeader     Kind = "block_header"
+	KindBlock           Kind = "block"
+	KindCollection      Kind = "collection"
+	KindExecutionResult Kind = "execution_result"
+)
+
+// Stats contains the cache statistics of an entity kind.
+type Stats struct {
+	// Hits is the number of requests served from the store.
+	Hits uint64
+	// Misses is the number of requests forwarded to the wrapped client.
+	Misses uint64
+	// Stored is the number of entities added to the store.
+	Stored uint64
+	// Errors is the number of failed store reads and writes, these requests are served by the wrapped client.
+	Errors uint64
+}
+
+// DefaultSealedRefreshInterval is the minimum interval between two refreshes of the latest sealed height.
+const DefaultSealedRefreshInterval = time.Second
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithSealedRefreshInterval sets the minimum interval between two requests for the latest
+// sealed block, made to decide whether a block more recent than the last known sealed block
+// can be cached.
+func WithSealedRefreshInterval(interval time.Duration) Option {
+	return func(c *Client) {
+		c.refreshInterval = interval
+	}
+}
+
+// Client is an access.Client caching immutable entities in a store.
+//
+// Methods that are not cached are passed through to the wrapped client.
+type Client struct {
+	access.Client
+	store           Store
+	refreshInterval time.Duration
+
+	mu           sync.Mutex
+	sealedHeight uint64
+	refreshedAt  time.Time
+	stats        map[Kind]*Stats
+}
+
+var _ access.Client = (*Client)(nil)
+
+// NewClient wraps the provided client with a cache backed by the store.
+func NewClient(client access.Client, store Store, opts ...Option) (*Client, error) {
+	if store == nil {
+		return nil, fmt.Errorf("cache: a store must be provided")
+	}
+
+	c := &Client{
+		Client:          client,
+		store:           store,
+		refreshInterval: DefaultSealedRefreshInterval,
+		stats:           make(map[Kind]*Stats),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c, nil
+}
+
+// Stats returns the cache statistics of every entity kind.
+func (c *Client) Stats() map[Kind]Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stats := make(map[Kind]Stats, len(c.stats))
+	for kind, s := range c.stats {
+		stats[kind] = *s
+	}
+
+	return stats
+}
+
+func (c *Client) update(kind Kind, f func(s *Stats)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	s, ok := c.stats[kind]
+	if !ok {
+		s = &Stats{}
+		c.stats[kind] = s
+	}
+	f(s)
+}
+
+func key(kind Kind, by string, value interface{}) string {
+	return fmt.Sprintf("%s/%s/%v", kind, by, value)
+}
+
+// load decodes the entity stored for the key into v, it reports whether the entity was found.
+func (c *Client) load(kind Kind, key string, v interface{}) bool {
+	data, ok, err := c.store.Get(key)
+	if err == nil && ok {
+		err = gob.NewDecoder(bytes.NewReader(data)).Decode(v)
+	}
+
+	c.update(kind, func(s *Stats) {
+		switch {
+		case err != nil:
+			s.Errors++
+			s.Misses++
+		case ok:
+			s.Hits++
+		default:
+			s.Misses++
+		}
+	})
+
+	return err == nil && ok
+}
+
+// save stores the entity under all the provided keys.
+func (c *Client) save(kind Kind, v interface{}, keys ...string) {
+	var buf bytes.Buffer
+	err := gob.NewEncoder(&buf).Encode(v)
+
+	for _, k := range keys {
+		if err != nil {
+			break
+		}
+		err = c.store.Set(k, buf.Bytes())
+	}
+
+	c.update(kind, func(s *Stats) {
+		if err != nil {
+			s.Errors++
+			return
+		}
+		s.Stored++
+	})
+}
+
+// observeSealed records the height of a block known to be sealed.
+func (c *Client) observeSealed(height uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if height > c.sealedHeight {
+		c.sealedHeight = height
+	}
+}
+
+// isSealed reports whether the block is sealed, refreshing the latest sealed height if needed.
+func (c *Client) isSealed(ctx context.Context, header flow.BlockHeader) bool {
+	if header.Status == flow.BlockStatusSealed {
+		c.observeSealed(header.Height)
+		return true
+	}
+
+	c.mu.Lock()
+	sealed := header.Height <= c.sealedHeight
+	refresh := !sealed && time.Since(c.refreshedAt) >= c.refreshInterval
+	if refresh {
+		c.refreshedAt = time.Now()
+	}
+	c.mu.Unlock()
+
+	if sealed || !refresh {
+		return sealed
+	}
+
+	latest, err := c.Client.GetLatestBlockHeader(ctx, true)
+	if err != nil {
+		return false
+	}
+	c.observeSealed(latest.Height)
+
+	return header.Height <= latest.Height
+}
+
+func (c *Client) GetLatestBlockHeader(ctx context.Context, isSealed bool) (*flow.BlockHeader, error) {
+	header, err := c.Client.GetLatestBlockHeader(ctx, isSealed)
+	if err == nil && isSealed {
+		c.observeSealed(header.Height)
+	}
+	return header, err
+}
+
+func (c *Client) GetLatestBlock(ctx context.Context, isSealed bool) (*flow.Block, error) {
+	block, err := c.Client.GetLatestBlock(ctx, isSealed)
+	if err == nil && isSealed {
+		c.observeSealed(block.Height)
+	}
+	return block, err
+}
+
+func (c *Client) GetBlockHeaderByID(ctx context.Context, blockID flow.Identifier) (*flow.BlockHeader, error) {
+	return c.getBlockHeader(ctx, key(KindBlockHeader, "id", blockID), func() (*flow.BlockHeader, error) {
+		return c.Client.GetBlockHeaderByID(ctx, blockID)
+	})
+}
+
+func (c *Client) GetBlockHeaderByHeight(ctx context.Context, height uint64) (*flow.BlockHeader, error) {
+	return c.getBlockHeader(ctx, key(KindBlockHeader, "height", height), func() (*flow.BlockHeader, error) {
+		return c.Client.GetBlockHeaderByHeight(ctx, height)
+	})
+}
+
+func (c *Client) getBlockHeader(
+	ctx context.Context,
+	k string,
+	fetch func() (*flow.BlockHeader, error),
+) (*flow.BlockHeader, error) {
+	var header flow.BlockHeader
+	if c.load(KindBlockHeader, k, &header) {
+		return &header, nil
+	}
+
+	fetched, err := fetch()
+	if err != nil {
+		return nil, err
+	}
+
+	if c.isSealed(ctx, *fetched) {
+		c.save(
+			KindBlockHeader,
+			fetched,
+			key(KindBlockHeader, "id", fetched.ID),
+			key(KindBlockHeader, "height", fetched.Height),
+		)
+	}
+
+	return fetched, nil
+}
+
+func (c *Client) GetBlockByID(ctx context.Context, blockID flow.Identifier) (*flow.Block, error) {
+	return c.getBlock(ctx, key(KindBlock, "id", blockID), func() (*flow.Block, error) {
+		return c.Client.GetBlockByID(ctx, blockID)
+	})
+}
+
+func (c *Client) GetBlockByHeight(ctx context.Context, height uint64) (*flow.Block, error) {
+	return c.getBlock(ctx, key(KindBlock, "height", height), func() (*flow.Block, error) {
+		return c.Client.GetBlockByHeight(ctx, height)
+	})
+}
+
+func (c *Client) getBlock(ctx context.Context, k string, fetch func() (*flow.Block, error)) (*flow.Block, error) {
+	var block flow.Block
+	if c.load(KindBlock, k, &block) {
+		return &block, nil
+	}
+
+	fetched, err := fetch()
+	if err != nil {
+		return nil, err
+	}
+
+	if c.isSealed(ctx, fetched.BlockHeader) {
+		c.save(
+			KindBlock,
+			fetched,
+			key(KindBlock, "id", fetched.ID),
+			key(KindBlock, "height", fetched.Height),
+		)
+	}
+
+	return fetched, nil
+}
+
+// GetCollection gets a collection by ID.
+//
+// Collections are identified by their content, so they are always cached.
+func (c *Client) GetCollection(ctx context.Context, colID flow.Identifier) (*flow.Collection, error) {
+	k := key(KindCollection, "id", colID)
+
+	var collection flow.Collection
+	if c.load(KindCollection, k, &collection) {
+		return &collection, nil
+	}
+
+	fetched, err := c.Client.GetCollection(ctx, colID)
+	if err != nil {
+		return nil, err
+	}
+
+	c.save(KindCollection, fetched, k)
+
+	return fetched, nil
+}
+
+// GetExecutionResultForBlockID gets the execution result of a block.
+//
+// The result is only cached once the block is sealed.
+func (c *Client) GetExecutionResultForBlockID(ctx context.Context, blockID flow.Identifier) (*flow.ExecutionResult, error) {
+	k := key(KindExecutionResult, "block_id", blockID)
+
+	var result flow.ExecutionResult
+	if c.load(KindExecutionResult, k, &result) {
+		return &result, nil
+	}
+
+	fetched, err := c.Client.GetExecutionResultForBlockID(ctx, blockID)
+	if err != nil {
+		return nil, err
+	}
+
+	header, err := c.GetBlockHeaderByID(ctx, blockID)
+	if err == nil && c.isSealed(ctx, *header) {
+		c.save(KindExecutionResult, fetched, k)
+	}
+
+	return fetched, nil
+}
@@ -0,0 +1,154 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2023 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cache
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/access"
+)
+
+// fakeClient implements the methods used by the tests, any other method panics.
+type fakeClient struct {
+	access.Client
+	sealedHeight uint64
+	calls        map[string]int
+}
+
+func newFakeClient(sealedHeight uint64) *fakeClient {
+	return &fakeClient{sealedHeight: sealedHeight, calls: make(map[string]int)}
+}
+
+func blockAt(height uint64) *flow.Block {
+	return &flow.Block{
+		BlockHeader: flow.BlockHeader{
+			ID:     flow.Identifier{byte(height)},
+			Height: height,
+		},
+		BlockPayload: flow.BlockPayload{
+			CollectionGuarantees: []*flow.CollectionGuarantee{{CollectionID: flow.Identifier{0xc}}},
+		},
+	}
+}
+
+func (f *fakeClient) GetLatestBlockHeader(_ context.Context, _ bool) (*flow.BlockHeader, error) {
+	f.calls["GetLatestBlockHeader"]++
+	return &blockAt(f.sealedHeight).BlockHeader, nil
+}
+
+func (f *fakeClient) GetBlockByHeight(_ context.Context, height uint64) (*flow.Block, error) {
+	f.calls["GetBlockByHeight"]++
+	return blockAt(height), nil
+}
+
+func (f *fakeClient) GetCollection(_ context.Context, colID flow.Identifier) (*flow.Collection, error) {
+	f.calls["GetCollection"]++
+	return &flow.Collection{TransactionIDs: []flow.Identifier{colID}}, nil
+}
+
+func TestClient_SealedBlock(t *testing.T) {
+	ctx := context.Background()
+	fake := newFakeClient(10)
+	store, err := NewLRUStore(10)
+	require.NoError(t, err)
+
+	client, err := NewClient(fake, store)
+	require.NoError(t, err)
+
+	first, err := client.GetBlockByHeight(ctx, 5)
+	require.NoError(t, err)
+
+	second, err := client.GetBlockByHeight(ctx, 5)
+	require.NoError(t, err)
+
+	assert.Equal(t, first, second)
+	assert.Equal(t, 1, fake.calls["GetBlockByHeight"])
+	assert.Equal(t, Stats{Hits: 1, Misses: 1, Stored: 1}, client.Stats()[KindBlock])
+}
+
+func TestClient_UnsealedBlock(t *testing.T) {
+	ctx := context.Background()
+	fake := newFakeClient(10)
+	store, err := NewLRUStore(10)
+	require.NoError(t, err)
+
+	client, err := NewClient(fake, store, WithSealedRefreshInterval(0))
+	require.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		_, err := client.GetBlockByHeight(ctx, 11)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 2, fake.calls["GetBlockByHeight"])
+	assert.Equal(t, 0, store.Len())
+
+	// once the block is sealed it is cached
+	fake.sealedHeight = 11
+	for i := 0; i < 2; i++ {
+		_, err := client.GetBlockByHeight(ctx, 11)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 3, fake.calls["GetBlockByHeight"])
+}
+
+func TestClient_Collection(t *testing.T) {
+	ctx := context.Background()
+	fake := newFakeClient(0)
+	store, err := NewDiskStore(t.TempDir())
+	require.NoError(t, err)
+
+	client, err := NewClient(fake, store)
+	require.NoError(t, err)
+
+	colID := flow.Identifier{1}
+	for i := 0; i < 3; i++ {
+		collection, err := client.GetCollection(ctx, colID)
+		require.NoError(t, err)
+		assert.Equal(t, []flow.Identifier{colID}, collection.TransactionIDs)
+	}
+
+	assert.Equal(t, 1, fake.calls["GetCollection"])
+	assert.Equal(t, Stats{Hits: 2, Misses: 1, Stored: 1}, client.Stats()[KindCollection])
+}
+
+func TestLRUStore_Eviction(t *testing.T) {
+	store, err := NewLRUStore(2)
+	require.NoError(t, err)
+
+	require.NoError(t, store.Set("a", []byte("a")))
+	require.NoError(t, store.Set("b", []byte("b")))
+
+	// touch "a" so "b" is the least recently used entry
+	_, ok, err := store.Get("a")
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	require.NoError(t, store.Set("c", []byte("c")))
+
+	_, ok, _ = store.Get("b")
+	assert.False(t, ok)
+	_, ok, _ = store.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, 2, store.Len())
+}
@@ -0,0 +1,166 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2023 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cache
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// A Store keeps the encoded entities cached by the client.
+//
+// Implementations must be safe for concurrent use.
+type Store interface {
+	// Get returns the value stored for the key, or false if the key is not stored.
+	Get(key string) ([]byte, bool, error)
+
+	// Set stores the value for the key.
+	Set(key string, value []byte) error
+}
+
+// LRUStore is an in-memory store evicting the least recently used entries.
+type LRUStore struct {
+	mu      sync.Mutex
+	size    int
+	order   *list.List
+	entries map[string]*list.Element
+}
+
+type lruEntry struct {
+	key   string
+	value []byte
+}
+
+var _ Store = (*LRUStore)(nil)
+
+// NewLRUStore creates an in-memory store holding at most size entries.
+func NewLRUStore(size int) (*LRUStore, error) {
+	if size <= 0 {
+		return nil, errors.New("cache: the store size must be positive")
+	}
+
+	return &LRUStore{
+		size:    size,
+		order:   list.New(),
+		entries: make(map[string]*list.Element, size),
+	}, nil
+}
+
+func (s *LRUStore) Get(key string) ([]byte, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.entries[key]
+	if !ok {
+		return nil, false, nil
+	}
+
+	s.order.MoveToFront(e)
+	return e.Value.(*lruEntry).value, true, nil
+}
+
+func (s *LRUStore) Set(key string, value []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if e, ok := s.entries[key]; ok {
+		e.Value.(*lruEntry).value = value
+		s.order.MoveToFront(e)
+		return nil
+	}
+
+	s.entries[key] = s.order.PushFront(&lruEntry{key: key, value: value})
+
+	if s.order.Len() > s.size {
+		oldest := s.order.Back()
+		s.order.Remove(oldest)
+		delete(s.entries, oldest.Value.(*lruEntry).key)
+	}
+
+	return nil
+}
+
+// Len returns the number of entries in the store.
+func (s *LRUStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.order.Len()
+}
+
+// DiskStore is a store keeping one file per entry in a directory.
+//
+// Entries are never evicted, which is safe since only immutable entities are cached.
+type DiskStore struct {
+	dir string
+}
+
+var _ Store = (*DiskStore)(nil)
+
+// NewDiskStore creates a store in the provided directory, creating it if needed.
+func NewDiskStore(dir string) (*DiskStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	return &DiskStore{dir: dir}, nil
+}
+
+// path returns the file of the key, keys are hashed so they are always valid file names.
+func (s *DiskStore) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(s.dir, hex.EncodeToString(sum[:]))
+}
+
+func (s *DiskStore) Get(key string) ([]byte, bool, error) {
+	value, err := os.ReadFile(s.path(key))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	return value, true, nil
+}
+
+func (s *DiskStore) Set(key string, value []byte) error {
+	// write to a temporary file first so concurrent readers never see a partial entry
+	tmp, err := os.CreateTemp(s.dir, "tmp-*")
+	if err != nil {
+		return err
+	}
+
+	if _, err := tmp.Write(value); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+
+	return os.Rename(tmp.Name(), s.path(key))
+}
@@ -0,0 +1,262 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2023 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package eventrange splits event queries over height ranges exceeding the access node limits.
+//
+// Access nodes reject event queries spanning more than DefaultMaxRange blocks. The gRPC and
+// HTTP clients use this package to transparently split larger ranges into pieces that are
+// fetched concurrently and reassembled in height order.
+package eventrange
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/onflow/flow-go-sdk"
+)
+
+const (
+	// DefaultMaxRange is the maximum number of heights queried at once by default,
+	// which is the limit enforced by the access nodes.
+	DefaultMaxRange = 250
+	// DefaultConcurrency is the default number of pieces fetched concurrently.
+	DefaultConcurrency = 4
+)
+
+// EndHeightPolicy defines how an end height above the latest sealed height is handled.
+type EndHeightPolicy int
+
+const (
+	// RejectEndHeight returns an EndHeightError when a query fails and its end height is above the latest sealed height.
+	RejectEndHeight EndHeightPolicy = iota
+	// ClampEndHeight lowers the end height to the latest sealed height before querying the events.
+	//
+	// If the start height is also above the latest sealed height no event is returned.
+	ClampEndHeight
+)
+
+// Options configures how event queries are split.
+type Options struct {
+	// MaxRange is the maximum number of heights queried at once.
+	MaxRange uint64
+	// Concurrency is the maximum number of pieces fetched concurrently.
+	Concurrency int
+	// EndHeight is the policy applied to end heights above the latest sealed height.
+	EndHeight EndHeightPolicy
+}
+
+// DefaultOptions returns the options used by the clients by default.
+func DefaultOptions() Options {
+	return Options{
+		MaxRange:    DefaultMaxRange,
+		Concurrency: DefaultConcurrency,
+		EndHeight:   RejectEndHeight,
+	}
+}
+
+// An EndHeightError is returned when the end height of a query is above the latest sealed height.
+type EndHeightError struct {
+	EndHeight          uint64
+	LatestSealedHeight uint64
+	// Err is the error returned by the access node, if any.
+	Err error
+}
+
+func (e EndHeightError) Error() string {
+	return fmt.Sprintf(
+		"end height %d is greater than the latest sealed height %d",
+		e.EndHeight,
+		e.LatestSealedHeight,
+	)
+}
+
+func (e EndHeightError) Unwrap() error {
+	return e.Err
+}
+
+// FetchFunc fetches the events of a height range that is within the access node limits.
+type FetchFunc func(ctx context.Context, startHeight uint64, endHeight uint64) ([]flow.BlockEvents, error)
+
+// LatestSealedFunc returns the latest sealed height.
+type LatestSealedFunc func(ctx context.Context) (uint64, error)
+
+// Split returns the ranges of at most maxRange heights covering the heights from start to end (inclusive).
+//
+// All the ranges are allocated at once, Fetch instead generates them as they are fetched.
+func Split(startHeight uint64, endHeight uint64, maxRange uint64) [][2]uint64 {
+	var ranges [][2]uint64
+	forEachRange(startHeight, endHeight, maxRange, func(start, end uint64) bool {
+		ranges = append(ranges, [2]uint64{start, end})
+		return true
+	})
+	return ranges
+}
+
+// forEachRange calls f for each range of at most maxRange heights covering the heights from
+// start to end (inclusive), in order, until f returns false.
+func forEachRange(startHeight uint64, endHeight uint64, maxRange uint64, f func(start, end uint64) bool) {
+	if startHeight > endHeight {
+		return
+	}
+	if maxRange == 0 {
+		f(startHeight, endHeight)
+		return
+	}
+
+	for start := startHeight; ; start += maxRange {
+		end := start + maxRange - 1
+		if end >= endHeight || end < start {
+			f(start, endHeight)
+			return
+		}
+		if !f(start, end) {
+			return
+		}
+	}
+}
+
+// Fetch fetches the events from start to end (inclusive), splitting the range if needed.
+//
+// The events of every piece are concatenated in height order. The first error stops the
+// fetch and is returned.
+func Fetch(
+	ctx context.Context,
+	startHeight uint64,
+	endHeight uint64,
+	opts Options,
+	latestSealed LatestSealedFunc,
+	fetch FetchFunc,
+) ([]flow.BlockEvents, error) {
+	if startHeight > endHeight {
+		return nil, fmt.Errorf("start height (%d) must be smaller than end height (%d)", startHeight, endHeight)
+	}
+
+	if opts.EndHeight == ClampEndHeight {
+		sealed, err := latestSealed(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if startHeight > sealed {
+			return []flow.BlockEvents{}, nil
+		}
+		if endHeight > sealed {
+			endHeight = sealed
+		}
+	}
+
+	events, err := fetchRanges(ctx, startHeight, endHeight, opts.MaxRange, opts.Concurrency, fetch)
+	if err != nil && opts.EndHeight == RejectEndHeight {
+		// only look up the latest sealed height once the query failed, so valid queries are not slowed down
+		if sealed, sealedErr := latestSealed(ctx); sealedErr == nil && endHeight > sealed {
+			return nil, EndHeightError{
+				EndHeight:          endHeight,
+				LatestSealedHeight: sealed,
+				Err:                err,
+			}
+		}
+	}
+
+	return events, err
+}
+
+// fetchRanges fetches the events from start to end in pieces of at most maxRange heights.
+//
+// The pieces are generated as they are handed to the workers rather than upfront, so that a
+// huge range does not allocate all its pieces, and the first error stops the generation.
+func fetchRanges(
+	ctx context.Context,
+	startHeight uint64,
+	endHeight uint64,
+	maxRange uint64,
+	concurrency int,
+	fetch FetchFunc,
+) ([]flow.BlockEvents, error) {
+	if maxRange == 0 || endHeight-startHeight < maxRange {
+		return fetch(ctx, startHeight, endHeight)
+	}
+
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type piece struct {
+		index      int
+		start, end uint64
+	}
+	pieces := make(chan piece)
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		results  = make(map[int][]flow.BlockEvents)
+		errOnce  sync.Once
+		firstErr error
+	)
+
+	// the number of pieces minus one fits in an uint64, unlike the number of pieces
+	lastPiece := (endHeight - startHeight) / maxRange
+	for w := 0; w < concurrency && uint64(w) <= lastPiece; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for p := range pieces {
+				events, err := fetch(ctx, p.start, p.end)
+				if err != nil {
+					errOnce.Do(func() {
+						firstErr = err
+						cancel()
+					})
+					continue
+				}
+				mu.Lock()
+				results[p.index] = events
+				mu.Unlock()
+			}
+		}()
+	}
+
+	count := 0
+	forEachRange(startHeight, endHeight, maxRange, func(start, end uint64) bool {
+		select {
+		case pieces <- piece{index: count, start: start, end: end}:
+			count++
+		case <-ctx.Done():
+		}
+		return ctx.Err() == nil
+	})
+	close(pieces)
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	events := make([]flow.BlockEvents, 0)
+	for i := 0; i < count; i++ {
+		events = append(events, results[i]...)
+	}
+
+	return events, nil
+}
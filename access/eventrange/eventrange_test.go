@@ -0,0 +1,133 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2023 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package eventrange
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+)
+
+// fakeNode serves one BlockEvents per height and rejects ranges above its limit or latest sealed height.
+type fakeNode struct {
+	mu       sync.Mutex
+	sealed   uint64
+	maxRange uint64
+	calls    [][2]uint64
+}
+
+func (n *fakeNode) latestSealed(context.Context) (uint64, error) {
+	return n.sealed, nil
+}
+
+func (n *fakeNode) fetch(_ context.Context, start uint64, end uint64) ([]flow.BlockEvents, error) {
+	n.mu.Lock()
+	n.calls = append(n.calls, [2]uint64{start, end})
+	n.mu.Unlock()
+
+	if end-start+1 > n.maxRange {
+		return nil, errors.New("range too large")
+	}
+	if end > n.sealed {
+		return nil, errors.New("end height not sealed")
+	}
+
+	events := make([]flow.BlockEvents, 0, end-start+1)
+	for h := start; h <= end; h++ {
+		events = append(events, flow.BlockEvents{Height: h})
+	}
+	return events, nil
+}
+
+func TestSplit(t *testing.T) {
+	assert.Equal(t, [][2]uint64{{0, 9}}, Split(0, 9, 250))
+	assert.Equal(t, [][2]uint64{{0, 3}, {4, 7}, {8, 9}}, Split(0, 9, 4))
+	assert.Equal(t, [][2]uint64{{5, 5}}, Split(5, 5, 4))
+	assert.Nil(t, Split(6, 5, 4))
+}
+
+func TestFetch_Split(t *testing.T) {
+	node := &fakeNode{sealed: 2000, maxRange: 250}
+
+	events, err := Fetch(context.Background(), 100, 1099, DefaultOptions(), node.latestSealed, node.fetch)
+	require.NoError(t, err)
+
+	require.Len(t, events, 1000)
+	for i, e := range events {
+		assert.Equal(t, uint64(100+i), e.Height)
+	}
+	assert.Len(t, node.calls, 4)
+}
+
+func TestFetch_EndHeight(t *testing.T) {
+	t.Run("Reject", func(t *testing.T) {
+		node := &fakeNode{sealed: 500, maxRange: 250}
+
+		_, err := Fetch(context.Background(), 400, 600, DefaultOptions(), node.latestSealed, node.fetch)
+
+		var endErr EndHeightError
+		require.ErrorAs(t, err, &endErr)
+		assert.Equal(t, uint64(600), endErr.EndHeight)
+		assert.Equal(t, uint64(500), endErr.LatestSealedHeight)
+	})
+
+	t.Run("Reject huge range", func(t *testing.T) {
+		node := &fakeNode{sealed: 500, maxRange: 250}
+		endHeight := uint64(math.MaxUint64 - 2)
+
+		_, err := Fetch(context.Background(), 400, endHeight, DefaultOptions(), node.latestSealed, node.fetch)
+
+		var endErr EndHeightError
+		require.ErrorAs(t, err, &endErr)
+		assert.Equal(t, endHeight, endErr.EndHeight)
+
+		// the pieces are generated lazily, so the first failures stop the query
+		assert.LessOrEqual(t, len(node.calls), 2*DefaultConcurrency)
+	})
+
+	t.Run("Clamp", func(t *testing.T) {
+		node := &fakeNode{sealed: 500, maxRange: 250}
+		opts := DefaultOptions()
+		opts.EndHeight = ClampEndHeight
+
+		events, err := Fetch(context.Background(), 400, 600, opts, node.latestSealed, node.fetch)
+		require.NoError(t, err)
+		assert.Len(t, events, 101)
+
+		events, err = Fetch(context.Background(), 501, 600, opts, node.latestSealed, node.fetch)
+		require.NoError(t, err)
+		assert.Empty(t, events)
+	})
+}
+
+func TestFetch_Error(t *testing.T) {
+	node := &fakeNode{sealed: 2000, maxRange: 100}
+	opts := DefaultOptions()
+	opts.MaxRange = 200
+
+	_, err := Fetch(context.Background(), 0, 999, opts, node.latestSealed, node.fetch)
+	assert.EqualError(t, err, "range too large")
+}
@@ -38,6 +38,11 @@ func blockToMessage(b flow.Block) (*entities.Block, error) {
 
 	t := timestamppb.New(b.BlockHeader.Timestamp)
 
+	header, err := blockHeaderToMessage(b.BlockHeader)
+	if err != nil {
+		return nil, err
+	}
+
 	return &entities.Block{
 		Id:                   b.BlockHeader.ID.Bytes(),
 		ParentId:             b.BlockHeader.ParentID.Bytes(),
@@ -45,6 +50,7 @@ func blockToMessage(b flow.Block) (*entities.Block, error) {
 		Timestamp:            t,
 		CollectionGuarantees: collectionGuaranteesToMessages(b.BlockPayload.CollectionGuarantees),
 		BlockSeals:           blockSealsToMessages(b.BlockPayload.Seals),
+		BlockHeader:          header,
 	}, nil
 }
 
@@ -63,6 +69,15 @@ func messageToBlock(m *entities.Block) (flow.Block, error) {
 		Timestamp: timestamp,
 	}
 
+	// the full header is only included by newer access nodes
+	if m.GetBlockHeader() != nil {
+		fullHeader, err := messageToBlockHeader(m.GetBlockHeader())
+		if err != nil {
+			return flow.Block{}, err
+		}
+		header = &fullHeader
+	}
+
 	guarantees, err := messagesToCollectionGuarantees(m.GetCollectionGuarantees())
 	if err != nil {
 		return flow.Block{}, err
@@ -88,10 +103,19 @@ func blockHeaderToMessage(b flow.BlockHeader) (*entities.BlockHeader, error) {
 	t := timestamppb.New(b.Timestamp)
 
 	return &entities.BlockHeader{
-		Id:        b.ID.Bytes(),
-		ParentId:  b.ParentID.Bytes(),
-		Height:    b.Height,
-		Timestamp: t,
+		Id:                 b.ID.Bytes(),
+		ParentId:           b.ParentID.Bytes(),
+		Height:             b.Height,
+		Timestamp:          t,
+		PayloadHash:        b.PayloadHash.Bytes(),
+		View:               b.View,
+		ParentView:         b.ParentView,
+		ParentVoterIndices: b.ParentVoterIndices,
+		ParentVoterIds:     identifiersToMessages(b.ParentVoterIDs),
+		ParentVoterSigData: b.ParentVoterSigData,
+		ProposerId:         b.ProposerID.Bytes(),
+		ProposerSigData:    b.ProposerSigData,
+		ChainId:            string(b.ChainID),
 	}, nil
 }
 
@@ -107,10 +131,19 @@ func messageToBlockHeader(m *entities.BlockHeader) (flow.BlockHeader, error) {
 	}
 
 	return flow.BlockHeader{
-		ID:        flow.HashToID(m.GetId()),
-		ParentID:  flow.HashToID(m.GetParentId()),
-		Height:    m.GetHeight(),
-		Timestamp: timestamp,
+		ID:                 flow.HashToID(m.GetId()),
+		ParentID:           flow.HashToID(m.GetParentId()),
+		Height:             m.GetHeight(),
+		Timestamp:          timestamp,
+		PayloadHash:        flow.HashToID(m.GetPayloadHash()),
+		View:               m.GetView(),
+		ParentView:         m.GetParentView(),
+		ParentVoterIndices: m.GetParentVoterIndices(),
+		ParentVoterIDs:     messagesToIdentifiers(m.GetParentVoterIds()),
+		ParentVoterSigData: m.GetParentVoterSigData(),
+		ProposerID:         flow.HashToID(m.GetProposerId()),
+		ProposerSigData:    m.GetProposerSigData(),
+		ChainID:            flow.ChainID(m.GetChainId()),
 	}, nil
 }
 
@@ -174,14 +207,24 @@ func messageToCollection(m *entities.Collection) (flow.Collection, error) {
 
 func collectionGuaranteeToMessage(g flow.CollectionGuarantee) *entities.CollectionGuarantee {
 	return &entities.CollectionGuarantee{
-		CollectionId: g.CollectionID.Bytes(),
+		CollectionId:     g.CollectionID.Bytes(),
+		ReferenceBlockId: g.ReferenceBlockID.Bytes(),
+		Signature:        g.Signature,
+		SignerIndices:    g.SignerIndices,
+		SignerIds:        identifiersToMessages(g.SignerIDs),
+		Signatures:       g.Signatures,
 	}
 }
 
 func blockSealToMessage(g flow.BlockSeal) *entities.BlockSeal {
 	return &entities.BlockSeal{
-		BlockId:            g.BlockID.Bytes(),
-		ExecutionReceiptId: g.ExecutionReceiptID.Bytes(),
+		BlockId:                    g.BlockID.Bytes(),
+		ExecutionReceiptId:         g.ExecutionReceiptID.Bytes(),
+		ResultId:                   g.ResultID.Bytes(),
+		FinalState:                 flow.Identifier(g.FinalState).Bytes(),
+		ExecutionReceiptSignatures: g.ExecutionReceiptSignatures,
+		ResultApprovalSignatures:   g.ResultApprovalSignatures,
+		AggregatedApprovalSigs:     aggregatedSignaturesToMessages(g.AggregatedApprovalSigs),
 	}
 }
 
@@ -191,7 +234,12 @@ func messageToCollectionGuarantee(m *entities.CollectionGuarantee) (flow.Collect
 	}
 
 	return flow.CollectionGuarantee{
-		CollectionID: flow.HashToID(m.CollectionId),
+		CollectionID:     flow.HashToID(m.CollectionId),
+		ReferenceBlockID: flow.HashToID(m.ReferenceBlockId),
+		Signature:        m.Signature,
+		SignerIndices:    m.SignerIndices,
+		SignerIDs:        messagesToIdentifiers(m.SignerIds),
+		Signatures:       m.Signatures,
 	}, nil
 }
 
@@ -200,12 +248,47 @@ func messageToBlockSeal(m *entities.BlockSeal) (flow.BlockSeal, error) {
 		return flow.BlockSeal{}, errEmptyMessage
 	}
 
+	aggregatedSigs, err := messagesToAggregatedSignatures(m.AggregatedApprovalSigs)
+	if err != nil {
+		return flow.BlockSeal{}, err
+	}
+
 	return flow.BlockSeal{
-		BlockID:            flow.BytesToID(m.BlockId),
-		ExecutionReceiptID: flow.BytesToID(m.ExecutionReceiptId),
+		BlockID:                    flow.BytesToID(m.BlockId),
+		ExecutionReceiptID:         flow.BytesToID(m.ExecutionReceiptId),
+		ResultID:                   flow.BytesToID(m.ResultId),
+		FinalState:                 flow.BytesToStateCommitment(m.FinalState),
+		ExecutionReceiptSignatures: m.ExecutionReceiptSignatures,
+		ResultApprovalSignatures:   m.ResultApprovalSignatures,
+		AggregatedApprovalSigs:     aggregatedSigs,
 	}, nil
 }
 
+func aggregatedSignaturesToMessages(l []*flow.AggregatedSignature) []*entities.AggregatedSignature {
+	results := make([]*entities.AggregatedSignature, len(l))
+	for i, item := range l {
+		results[i] = &entities.AggregatedSignature{
+			VerifierSignatures: item.VerifierSignatures,
+			SignerIds:          identifiersToMessages(item.SignerIDs),
+		}
+	}
+	return results
+}
+
+func messagesToAggregatedSignatures(l []*entities.AggregatedSignature) ([]*flow.AggregatedSignature, error) {
+	results := make([]*flow.AggregatedSignature, len(l))
+	for i, item := range l {
+		if item == nil {
+			return nil, errEmptyMessage
+		}
+		results[i] = &flow.AggregatedSignature{
+			VerifierSignatures: item.VerifierSignatures,
+			SignerIDs:          messagesToIdentifiers(item.SignerIds),
+		}
+	}
+	return results, nil
+}
+
 func collectionGuaranteesToMessages(l []*flow.CollectionGuarantee) []*entities.CollectionGuarantee {
 	results := make([]*entities.CollectionGuarantee, len(l))
 	for i, item := range l {
@@ -340,7 +423,7 @@ func transactionToMessage(t flow.Transaction) (*entities.Transaction, error) {
 
 	return &entities.Transaction{
 		Script:             t.Script,
-		Arguments:          t.Arguments,
+		Arguments:          t.CanonicalArguments(),
 		ReferenceBlockId:   t.ReferenceBlockID.Bytes(),
 		GasLimit:           t.GasLimit,
 		ProposalKey:        proposalKeyMessage,
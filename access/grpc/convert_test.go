@@ -0,0 +1,137 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2023 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package grpc
+
+import (
+	"testing"
+
+	"github.com/onflow/flow/protobuf/go/flow/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/test"
+)
+
+func TestConvert_BlockHeader(t *testing.T) {
+	t.Run("round trip", func(t *testing.T) {
+		header := test.BlockHeaderGenerator().New()
+
+		msg, err := blockHeaderToMessage(header)
+		require.NoError(t, err)
+
+		assert.Equal(t, header.PayloadHash.Bytes(), msg.PayloadHash)
+		assert.Equal(t, header.View, msg.View)
+		assert.Equal(t, header.ParentView, msg.ParentView)
+		assert.Equal(t, header.ParentVoterIndices, msg.ParentVoterIndices)
+		assert.Equal(t, [][]byte{header.ParentVoterIDs[0].Bytes(), header.ParentVoterIDs[1].Bytes()}, msg.ParentVoterIds)
+		assert.Equal(t, header.ParentVoterSigData, msg.ParentVoterSigData)
+		assert.Equal(t, header.ProposerID.Bytes(), msg.ProposerId)
+		assert.Equal(t, header.ProposerSigData, msg.ProposerSigData)
+		assert.Equal(t, string(flow.Emulator), msg.ChainId)
+
+		converted, err := messageToBlockHeader(msg)
+		require.NoError(t, err)
+		assert.Equal(t, header, converted)
+	})
+
+	t.Run("missing consensus fields", func(t *testing.T) {
+		header := test.BlockHeaderGenerator().New()
+
+		msg, err := blockHeaderToMessage(flow.BlockHeader{
+			ID:        header.ID,
+			ParentID:  header.ParentID,
+			Height:    header.Height,
+			Timestamp: header.Timestamp,
+		})
+		require.NoError(t, err)
+
+		converted, err := messageToBlockHeader(msg)
+		require.NoError(t, err)
+		assert.Equal(t, header.ID, converted.ID)
+		assert.Equal(t, flow.EmptyID, converted.PayloadHash)
+		assert.Equal(t, flow.EmptyID, converted.ProposerID)
+		assert.Empty(t, converted.ParentVoterIDs)
+		assert.Empty(t, converted.ChainID)
+	})
+
+	t.Run("empty message", func(t *testing.T) {
+		_, err := messageToBlockHeader(nil)
+		assert.ErrorIs(t, err, errEmptyMessage)
+	})
+}
+
+func TestConvert_CollectionGuarantee(t *testing.T) {
+	guarantee := test.CollectionGuaranteeGenerator().New()
+
+	msg := collectionGuaranteeToMessage(*guarantee)
+	assert.Equal(t, guarantee.ReferenceBlockID.Bytes(), msg.ReferenceBlockId)
+	assert.Equal(t, guarantee.SignerIndices, msg.SignerIndices)
+	assert.Equal(t, [][]byte{guarantee.SignerIDs[0].Bytes(), guarantee.SignerIDs[1].Bytes()}, msg.SignerIds)
+	assert.Equal(t, guarantee.Signatures, msg.Signatures)
+
+	converted, err := messagesToCollectionGuarantees(collectionGuaranteesToMessages([]*flow.CollectionGuarantee{guarantee}))
+	require.NoError(t, err)
+	require.Len(t, converted, 1)
+	assert.Equal(t, guarantee.CollectionID, converted[0].CollectionID)
+	assert.Equal(t, guarantee.ReferenceBlockID, converted[0].ReferenceBlockID)
+	assert.Equal(t, guarantee.Signature, converted[0].Signature)
+	assert.Equal(t, guarantee.SignerIndices, converted[0].SignerIndices)
+	assert.Equal(t, guarantee.SignerIDs, converted[0].SignerIDs)
+	assert.Equal(t, guarantee.Signatures, converted[0].Signatures)
+
+	_, err = messageToCollectionGuarantee(nil)
+	assert.ErrorIs(t, err, errEmptyMessage)
+}
+
+func TestConvert_BlockSeal(t *testing.T) {
+	t.Run("round trip", func(t *testing.T) {
+		seal := test.BlockSealGenerator().New()
+		seal.ExecutionReceiptSignatures = [][]byte{{0x4}}
+		seal.ResultApprovalSignatures = [][]byte{{0x5}, {0x6}}
+
+		msg := blockSealToMessage(*seal)
+		assert.Equal(t, seal.ResultID.Bytes(), msg.ResultId)
+		assert.Equal(t, flow.Identifier(seal.FinalState).Bytes(), msg.FinalState)
+		require.Len(t, msg.AggregatedApprovalSigs, 1)
+		assert.Equal(t, seal.AggregatedApprovalSigs[0].VerifierSignatures, msg.AggregatedApprovalSigs[0].VerifierSignatures)
+
+		converted, err := messagesToBlockSeals(blockSealsToMessages([]*flow.BlockSeal{seal}))
+		require.NoError(t, err)
+		require.Len(t, converted, 1)
+		assert.Equal(t, seal.BlockID, converted[0].BlockID)
+		assert.Equal(t, seal.ExecutionReceiptID, converted[0].ExecutionReceiptID)
+		assert.Equal(t, seal.ResultID, converted[0].ResultID)
+		assert.Equal(t, seal.FinalState, converted[0].FinalState)
+		assert.Equal(t, seal.ExecutionReceiptSignatures, converted[0].ExecutionReceiptSignatures)
+		assert.Equal(t, seal.ResultApprovalSignatures, converted[0].ResultApprovalSignatures)
+
+		require.Len(t, converted[0].AggregatedApprovalSigs, 1)
+		assert.Equal(t, seal.AggregatedApprovalSigs[0].VerifierSignatures, converted[0].AggregatedApprovalSigs[0].VerifierSignatures)
+		assert.Equal(t, seal.AggregatedApprovalSigs[0].SignerIDs, converted[0].AggregatedApprovalSigs[0].SignerIDs)
+	})
+
+	t.Run("empty messages", func(t *testing.T) {
+		_, err := messageToBlockSeal(nil)
+		assert.ErrorIs(t, err, errEmptyMessage)
+
+		_, err = messagesToAggregatedSignatures([]*entities.AggregatedSignature{nil})
+		assert.ErrorIs(t, err, errEmptyMessage)
+	})
+}
@@ -29,6 +29,7 @@ import (
 	"google.golang.org/grpc"
 
 	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/access/eventrange"
 )
 
 // RPCClient is an RPC client for the Flow Access API.
@@ -44,6 +45,7 @@ type BaseClient struct {
 	rpcClient   RPCClient
 	close       func() error
 	jsonOptions []json.Option
+	eventRange  eventrange.Options
 }
 
 // NewBaseClient creates a new gRPC handler for network communication.
@@ -59,14 +61,16 @@ func NewBaseClient(url string, opts ...grpc.DialOption) (*BaseClient, error) {
 		rpcClient:   grpcClient,
 		close:       func() error { return conn.Close() },
 		jsonOptions: []json.Option{json.WithAllowUnstructuredStaticTypes(true)},
+		eventRange:  eventrange.DefaultOptions(),
 	}, nil
 }
 
 // NewFromRPCClient initializes a Flow client using a pre-configured gRPC provider.
 func NewFromRPCClient(rpcClient RPCClient) *BaseClient {
 	return &BaseClient{
-		rpcClient: rpcClient,
-		close:     func() error { return nil },
+		rpcClient:  rpcClient,
+		close:      func() error { return nil },
+		eventRange: eventrange.DefaultOptions(),
 	}
 }
 
@@ -74,6 +78,11 @@ func (c *BaseClient) SetJSONOptions(options []json.Option) {
 	c.jsonOptions = options
 }
 
+// SetEventRangeOptions sets how GetEventsForHeightRange splits ranges exceeding the access node limits.
+func (c *BaseClient) SetEventRangeOptions(options eventrange.Options) {
+	c.eventRange = options
+}
+
 // Close closes the client connection.
 func (c *BaseClient) Close() error {
 	return c.close()
@@ -446,23 +455,42 @@ type EventRangeQuery struct {
 	EndHeight uint64
 }
 
+// GetEventsForHeightRange gets the events of the given type in the height range.
+//
+// Ranges larger than the access node limit are split and the pieces are fetched concurrently,
+// as configured with SetEventRangeOptions.
 func (c *BaseClient) GetEventsForHeightRange(
 	ctx context.Context,
 	query EventRangeQuery,
 	opts ...grpc.CallOption,
 ) ([]flow.BlockEvents, error) {
-	req := &access.GetEventsForHeightRangeRequest{
-		Type:        query.Type,
-		StartHeight: query.StartHeight,
-		EndHeight:   query.EndHeight,
-	}
+	return eventrange.Fetch(
+		ctx,
+		query.StartHeight,
+		query.EndHeight,
+		c.eventRange,
+		func(ctx context.Context) (uint64, error) {
+			header, err := c.GetLatestBlockHeader(ctx, true, opts...)
+			if err != nil {
+				return 0, err
+			}
+			return header.Height, nil
+		},
+		func(ctx context.Context, startHeight uint64, endHeight uint64) ([]flow.BlockEvents, error) {
+			req := &access.GetEventsForHeightRangeRequest{
+				Type:        query.Type,
+				StartHeight: startHeight,
+				EndHeight:   endHeight,
+			}
 
-	res, err := c.rpcClient.GetEventsForHeightRange(ctx, req, opts...)
-	if err != nil {
-		return nil, newRPCError(err)
-	}
+			res, err := c.rpcClient.GetEventsForHeightRange(ctx, req, opts...)
+			if err != nil {
+				return nil, newRPCError(err)
+			}
 
-	return getEventsResult(res, c.jsonOptions)
+			return getEventsResult(res, c.jsonOptions)
+		},
+	)
 }
 
 func (c *BaseClient) GetEventsForBlockIDs(
@@ -538,6 +566,7 @@ func (c *BaseClient) GetExecutionResultForBlockID(ctx context.Context, blockID f
 		chunks[i] = &flow.Chunk{
 			CollectionIndex:      uint(chunk.CollectionIndex),
 			StartState:           flow.BytesToStateCommitment(chunk.StartState),
+			EventCollection:      flow.BytesToID(chunk.EventCollection),
 			BlockID:              flow.BytesToID(chunk.BlockId),
 			TotalComputationUsed: chunk.TotalComputationUsed,
 			NumberOfTransactions: uint16(chunk.NumberOfTransactions),
@@ -558,5 +587,6 @@ func (c *BaseClient) GetExecutionResultForBlockID(ctx context.Context, blockID f
 		BlockID:          flow.BytesToID(er.ExecutionResult.BlockId),
 		Chunks:           chunks,
 		ServiceEvents:    serviceEvents,
+		ExecutionDataID:  flow.BytesToID(er.ExecutionResult.ExecutionDataId),
 	}, nil
 }
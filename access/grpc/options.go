@@ -0,0 +1,124 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2023 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package grpc
+
+import (
+	"context"
+	"path"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/onflow/flow-go-sdk/access/logging"
+	"github.com/onflow/flow-go-sdk/access/ratelimit"
+)
+
+// WithRateLimiter returns a dial option limiting the requests made by the client with the provided limiter.
+//
+// The option must be passed to NewClient or NewBaseClient together with the other dial options:
+//
+//	limiter := ratelimit.NewLimiter(
+//	    ratelimit.WithRate(ratelimit.FamilyScripts, ratelimit.Rate{PerSecond: 10, Burst: 5}),
+//	    ratelimit.WithMaxInFlight(20),
+//	)
+//	client, err := grpc.NewBaseClient(host, creds, grpc.WithRateLimiter(limiter))
+func WithRateLimiter(limiter *ratelimit.Limiter) grpc.DialOption {
+	return grpc.WithChainUnaryInterceptor(
+		func(
+			ctx context.Context,
+			method string,
+			req, reply interface{},
+			cc *grpc.ClientConn,
+			invoker grpc.UnaryInvoker,
+			opts ...grpc.CallOption,
+		) error {
+			release, err := limiter.Wait(ctx, ratelimit.MethodFamily(methodName(method)))
+			if err != nil {
+				return err
+			}
+			defer release()
+
+			return invoker(ctx, method, req, reply, cc, opts...)
+		},
+	)
+}
+
+// WithLogger returns a dial option logging the requests made by the client with the provided hook.
+//
+// Request and response messages are logged in their JSON representation using the protobuf field names.
+func WithLogger(hook *logging.Hook) grpc.DialOption {
+	return grpc.WithChainUnaryInterceptor(
+		func(
+			ctx context.Context,
+			method string,
+			req, reply interface{},
+			cc *grpc.ClientConn,
+			invoker grpc.UnaryInvoker,
+			opts ...grpc.CallOption,
+		) error {
+			if !hook.Enabled(logging.LevelRequests) {
+				return invoker(ctx, method, req, reply, cc, opts...)
+			}
+
+			name := methodName(method)
+			target := cc.Target()
+
+			hook.Request(ctx, name, target, messageBody(hook, req))
+			start := time.Now()
+
+			err := invoker(ctx, method, req, reply, cc, opts...)
+
+			var body []byte
+			if err == nil {
+				body = messageBody(hook, reply)
+			}
+			hook.Response(ctx, name, target, status.Code(err).String(), time.Since(start), body, err)
+
+			return err
+		},
+	)
+}
+
+var protoNamesMarshaler = protojson.MarshalOptions{UseProtoNames: true}
+
+// messageBody encodes the message for logging, only if bodies are logged.
+func messageBody(hook *logging.Hook, msg interface{}) []byte {
+	if !hook.Enabled(logging.LevelBodies) {
+		return nil
+	}
+
+	m, ok := msg.(proto.Message)
+	if !ok {
+		return nil
+	}
+
+	body, err := protoNamesMarshaler.Marshal(m)
+	if err != nil {
+		return nil
+	}
+	return body
+}
+
+// methodName returns the name of the method from the full gRPC method, e.g. "/flow.access.AccessAPI/Ping".
+func methodName(fullMethod string) string {
+	return path.Base(fullMethod)
+}
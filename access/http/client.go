@@ -36,8 +36,8 @@ const (
 
 // NewClient creates an HTTP client exposing all the common access APIs.
 // Client will use provided host for connection.
-func NewClient(host string) (*Client, error) {
-	client, err := NewBaseClient(host)
+func NewClient(host string, opts ...ClientOption) (*Client, error) {
+	client, err := NewBaseClient(host, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -50,48 +50,88 @@ func toContracts(contracts map[string]string) (map[string][]byte, error) {
 	return decoded, nil
 }
 
-func toBlockHeader(header *models.BlockHeader, blockStatus string) *flow.BlockHeader {
-	return &flow.BlockHeader{
-		ID:        flow.HexToID(header.Id),
-		ParentID:  flow.HexToID(header.ParentId),
-		Height:    mustToUint(header.Height),
-		Timestamp: header.Timestamp,
-		Status:    flow.BlockStatusFromString(blockStatus),
+func toBlockHeader(header *models.BlockHeader, blockStatus string) (*flow.BlockHeader, error) {
+	// the REST API only exposes the parent voter signature out of the consensus fields
+	parentVoterSigData, err := base64.StdEncoding.DecodeString(header.ParentVoterSignature)
+	if err != nil {
+		return nil, err
 	}
+
+	return &flow.BlockHeader{
+		ID:                 flow.HexToID(header.Id),
+		ParentID:           flow.HexToID(header.ParentId),
+		Height:             mustToUint(header.Height),
+		Timestamp:          header.Timestamp,
+		Status:             flow.BlockStatusFromString(blockStatus),
+		ParentVoterSigData: parentVoterSigData,
+	}, nil
 }
 
-func toCollectionGuarantees(guarantees []models.CollectionGuarantee) []*flow.CollectionGuarantee {
+func toCollectionGuarantees(guarantees []models.CollectionGuarantee) ([]*flow.CollectionGuarantee, error) {
 	flowGuarantees := make([]*flow.CollectionGuarantee, len(guarantees))
 
 	for i, guarantee := range guarantees {
+		signature, err := base64.StdEncoding.DecodeString(guarantee.Signature)
+		if err != nil {
+			return nil, err
+		}
+
 		flowGuarantees[i] = &flow.CollectionGuarantee{
-			flow.HexToID(guarantee.CollectionId),
+			CollectionID: flow.HexToID(guarantee.CollectionId),
+			Signature:    signature,
+			SignerIDs:    toIdentifiers(guarantee.SignerIds),
+		}
+	}
+
+	return flowGuarantees, nil
+}
+
+func toIdentifiers(ids []string) []flow.Identifier {
+	flowIDs := make([]flow.Identifier, len(ids))
+	for i, id := range ids {
+		flowIDs[i] = flow.HexToID(id)
+	}
+	return flowIDs
+}
+
+func toAggregatedSignatures(sigs []models.AggregatedSignature) ([]*flow.AggregatedSignature, error) {
+	flowSigs := make([]*flow.AggregatedSignature, len(sigs))
+
+	for i, sig := range sigs {
+		signatures := make([][]byte, len(sig.VerifierSignatures))
+		for j, ver := range sig.VerifierSignatures {
+			dec, err := base64.StdEncoding.DecodeString(ver)
+			if err != nil {
+				return nil, err
+			}
+			signatures[j] = dec
+		}
+
+		flowSigs[i] = &flow.AggregatedSignature{
+			VerifierSignatures: signatures,
+			SignerIDs:          toIdentifiers(sig.SignerIds),
 		}
 	}
 
-	return flowGuarantees
+	return flowSigs, nil
 }
 
 func toBlockSeals(seals []models.BlockSeal) ([]*flow.BlockSeal, error) {
 	flowSeal := make([]*flow.BlockSeal, len(seals))
 
 	for i, seal := range seals {
-		signatures := make([][]byte, 0)
-		for _, sig := range seal.AggregatedApprovalSignatures {
-			for _, ver := range sig.VerifierSignatures {
-				dec, err := base64.StdEncoding.DecodeString(ver)
-				if err != nil {
-					return nil, err
-				}
-				signatures = append(signatures, dec)
-			}
+		aggregatedSigs, err := toAggregatedSignatures(seal.AggregatedApprovalSignatures)
+		if err != nil {
+			return nil, err
 		}
 
 		flowSeal[i] = &flow.BlockSeal{
 			BlockID: flow.HexToID(seal.BlockId),
-			// TODO: this needs to be changed to resultID
-			// https://github.com/onflow/flow-go/blob/3683183977f2ea769836d8a31997701b3dbced83/model/flow/seal.go#L42
-			ExecutionReceiptID: flow.HexToID(seal.ResultId),
+			// the REST API does not expose the receipt ID, the result ID is kept here for backward compatibility
+			ExecutionReceiptID:     flow.HexToID(seal.ResultId),
+			ResultID:               flow.HexToID(seal.ResultId),
+			FinalState:             flow.HexToStateCommitment(seal.FinalState),
+			AggregatedApprovalSigs: aggregatedSigs,
 		}
 	}
 
@@ -99,13 +139,18 @@ func toBlockSeals(seals []models.BlockSeal) ([]*flow.BlockSeal, error) {
 }
 
 func toBlockPayload(payload *models.BlockPayload) (*flow.BlockPayload, error) {
+	guarantees, err := toCollectionGuarantees(payload.CollectionGuarantees)
+	if err != nil {
+		return nil, err
+	}
+
 	seals, err := toBlockSeals(payload.BlockSeals)
 	if err != nil {
 		return nil, err
 	}
 
 	return &flow.BlockPayload{
-		CollectionGuarantees: toCollectionGuarantees(payload.CollectionGuarantees),
+		CollectionGuarantees: guarantees,
 		Seals:                seals,
 	}, nil
 }
@@ -124,13 +169,18 @@ func toBlocks(blocks []*models.Block) ([]*flow.Block, error) {
 }
 
 func toBlock(block *models.Block) (*flow.Block, error) {
+	header, err := toBlockHeader(block.Header, block.BlockStatus)
+	if err != nil {
+		return nil, err
+	}
+
 	payload, err := toBlockPayload(block.Payload)
 	if err != nil {
 		return nil, err
 	}
 
 	return &flow.Block{
-		BlockHeader:  *toBlockHeader(block.Header, block.BlockStatus),
+		BlockHeader:  *header,
 		BlockPayload: *payload,
 	}, nil
 }
@@ -357,7 +407,7 @@ func encodeTransaction(tx flow.Transaction) ([]byte, error) {
 
 	return json.Marshal(models.TransactionsBody{
 		Script:           encodeScript(tx.Script),
-		Arguments:        encodeArgs(tx.Arguments),
+		Arguments:        encodeArgs(tx.CanonicalArguments()),
 		ReferenceBlockId: tx.ReferenceBlockID.String(),
 		GasLimit:         fmt.Sprintf("%d", tx.GasLimit),
 		Payer:            tx.Payer.String(),
@@ -372,12 +422,18 @@ func encodeTransaction(tx flow.Transaction) ([]byte, error) {
 	})
 }
 
-func toExecutionResults(result models.ExecutionResult) *flow.ExecutionResult {
+func toExecutionResults(result models.ExecutionResult) (*flow.ExecutionResult, error) {
 	events := make([]*flow.ServiceEvent, len(result.Events))
 	for i, e := range result.Events {
+		// the REST API encodes the service event payload in base64
+		payload, err := base64.StdEncoding.DecodeString(e.Payload)
+		if err != nil {
+			return nil, err
+		}
+
 		events[i] = &flow.ServiceEvent{
 			Type:    e.Type_,
-			Payload: []byte(e.Payload),
+			Payload: payload,
 		}
 	}
 
@@ -387,6 +443,7 @@ func toExecutionResults(result models.ExecutionResult) *flow.ExecutionResult {
 		chunks[i] = &flow.Chunk{
 			CollectionIndex:      uint(mustToUint(chunk.CollectionIndex)),
 			StartState:           flow.HexToStateCommitment(chunk.StartState),
+			EventCollection:      flow.HexToID(chunk.EventCollection),
 			BlockID:              flow.HexToID(chunk.BlockId),
 			TotalComputationUsed: mustToUint(chunk.TotalComputationUsed),
 			NumberOfTransactions: uint16(mustToUint(chunk.NumberOfTransactions)),
@@ -400,5 +457,5 @@ func toExecutionResults(result models.ExecutionResult) *flow.ExecutionResult {
 		BlockID:          flow.HexToID(result.BlockId),
 		Chunks:           chunks,
 		ServiceEvents:    events,
-	}
+	}, nil
 }
@@ -0,0 +1,151 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2023 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package http
+
+import (
+	"encoding/base64"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/access/http/models"
+	"github.com/onflow/flow-go-sdk/test"
+)
+
+func TestConvert_BlockHeader(t *testing.T) {
+	header := test.BlockHeaderGenerator().New()
+
+	converted, err := toBlockHeader(&models.BlockHeader{
+		Id:                   header.ID.String(),
+		ParentId:             header.ParentID.String(),
+		Height:               fmt.Sprintf("%d", header.Height),
+		Timestamp:            header.Timestamp,
+		ParentVoterSignature: base64.StdEncoding.EncodeToString(header.ParentVoterSigData),
+	}, "BLOCK_SEALED")
+	require.NoError(t, err)
+
+	assert.Equal(t, header.ID, converted.ID)
+	assert.Equal(t, header.ParentID, converted.ParentID)
+	assert.Equal(t, header.Height, converted.Height)
+	assert.Equal(t, header.Timestamp, converted.Timestamp)
+	assert.Equal(t, flow.BlockStatusSealed, converted.Status)
+	assert.Equal(t, header.ParentVoterSigData, converted.ParentVoterSigData)
+
+	// the other consensus fields are not exposed by the REST API
+	assert.Equal(t, flow.EmptyID, converted.PayloadHash)
+	assert.Zero(t, converted.View)
+	assert.Zero(t, converted.ParentView)
+	assert.Empty(t, converted.ParentVoterIndices)
+	assert.Empty(t, converted.ParentVoterIDs)
+	assert.Equal(t, flow.EmptyID, converted.ProposerID)
+	assert.Empty(t, converted.ProposerSigData)
+	assert.Empty(t, converted.ChainID)
+
+	t.Run("invalid signature", func(t *testing.T) {
+		_, err := toBlockHeader(&models.BlockHeader{
+			Id:                   header.ID.String(),
+			Height:               "1",
+			ParentVoterSignature: "%%",
+		}, "BLOCK_SEALED")
+		assert.Error(t, err)
+
+		_, err = toBlock(&models.Block{
+			Header:  &models.BlockHeader{Height: "1", ParentVoterSignature: "%%"},
+			Payload: &models.BlockPayload{},
+		})
+		assert.Error(t, err)
+	})
+}
+
+func TestConvert_CollectionGuarantees(t *testing.T) {
+	guarantee := test.CollectionGuaranteeGenerator().New()
+
+	converted, err := toCollectionGuarantees([]models.CollectionGuarantee{{
+		CollectionId: guarantee.CollectionID.String(),
+		SignerIds:    []string{guarantee.SignerIDs[0].String(), guarantee.SignerIDs[1].String()},
+		Signature:    base64.StdEncoding.EncodeToString(guarantee.Signature),
+	}})
+	require.NoError(t, err)
+	assert.Equal(t, []*flow.CollectionGuarantee{{
+		CollectionID: guarantee.CollectionID,
+		Signature:    guarantee.Signature,
+		SignerIDs:    guarantee.SignerIDs,
+	}}, converted)
+
+	_, err = toCollectionGuarantees([]models.CollectionGuarantee{{Signature: "%%"}})
+	assert.Error(t, err)
+}
+
+func TestConvert_BlockSeals(t *testing.T) {
+	seal := test.BlockSealGenerator().New()
+	sigs := seal.AggregatedApprovalSigs[0]
+
+	aggregated := models.AggregatedSignature{
+		VerifierSignatures: []string{
+			base64.StdEncoding.EncodeToString(sigs.VerifierSignatures[0]),
+			base64.StdEncoding.EncodeToString(sigs.VerifierSignatures[1]),
+		},
+		SignerIds: []string{sigs.SignerIDs[0].String(), sigs.SignerIDs[1].String()},
+	}
+
+	convertedSigs, err := toAggregatedSignatures([]models.AggregatedSignature{aggregated})
+	require.NoError(t, err)
+	assert.Equal(t, []*flow.AggregatedSignature{sigs}, convertedSigs)
+
+	converted, err := toBlockSeals([]models.BlockSeal{{
+		BlockId:                      seal.BlockID.String(),
+		ResultId:                     seal.ResultID.String(),
+		FinalState:                   flow.Identifier(seal.FinalState).String(),
+		AggregatedApprovalSignatures: []models.AggregatedSignature{aggregated},
+	}})
+	require.NoError(t, err)
+	assert.Equal(t, []*flow.BlockSeal{{
+		BlockID: seal.BlockID,
+		// the REST API does not expose the receipt ID
+		ExecutionReceiptID:     seal.ResultID,
+		ResultID:               seal.ResultID,
+		FinalState:             seal.FinalState,
+		AggregatedApprovalSigs: []*flow.AggregatedSignature{sigs},
+	}}, converted)
+
+	aggregated.VerifierSignatures = []string{"%%"}
+	_, err = toBlockSeals([]models.BlockSeal{{AggregatedApprovalSignatures: []models.AggregatedSignature{aggregated}}})
+	assert.Error(t, err)
+}
+
+func TestConvert_ExecutionResults(t *testing.T) {
+	payload := []byte(`{"type":"Event","value":{}}`)
+
+	result, err := toExecutionResults(models.ExecutionResult{
+		Events: []models.Event{{
+			Type_:   "A.01.EpochSetup",
+			Payload: base64.StdEncoding.EncodeToString(payload),
+		}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []*flow.ServiceEvent{{Type: "A.01.EpochSetup", Payload: payload}}, result.ServiceEvents)
+
+	_, err = toExecutionResults(models.ExecutionResult{
+		Events: []models.Event{{Type_: "A.01.EpochSetup", Payload: string(payload)}},
+	})
+	assert.Error(t, err)
+}
@@ -30,6 +30,8 @@ import (
 	"time"
 
 	"github.com/onflow/flow-go-sdk/access/http/models"
+	"github.com/onflow/flow-go-sdk/access/logging"
+	"github.com/onflow/flow-go-sdk/access/ratelimit"
 
 	"github.com/pkg/errors"
 )
@@ -49,24 +51,54 @@ func (h HTTPError) Error() string {
 }
 
 type httpHandler struct {
-	client *http.Client
-	base   string
-	debug  bool
+	client  *http.Client
+	base    string
+	logger  *logging.Hook
+	limiter *ratelimit.Limiter
 }
 
-func newHandler(host string, debug bool) (*httpHandler, error) {
+func newHandler(host string, opts options) (*httpHandler, error) {
 	_, err := url.Parse(host)
 	if err != nil {
 		return nil, err
 	}
 
 	return &httpHandler{
-		client: http.DefaultClient,
-		base:   host,
-		debug:  debug,
+		client:  http.DefaultClient,
+		base:    host,
+		logger:  opts.logger,
+		limiter: opts.limiter,
 	}, nil
 }
 
+// resourceFamilies maps the REST API resources to the rate limited method families.
+var resourceFamilies = map[string]ratelimit.Family{
+	"blocks":              ratelimit.FamilyBlocks,
+	"collections":         ratelimit.FamilyBlocks,
+	"execution_results":   ratelimit.FamilyBlocks,
+	"transactions":        ratelimit.FamilyTransactions,
+	"transaction_results": ratelimit.FamilyTransactions,
+	"scripts":             ratelimit.FamilyScripts,
+	"events":              ratelimit.FamilyEvents,
+}
+
+// limit waits until the request to the provided URL is allowed by the rate limiter.
+func (h *httpHandler) limit(ctx context.Context, u *url.URL) (func(), error) {
+	if h.limiter == nil {
+		return func() {}, nil
+	}
+
+	family := ratelimit.FamilyOther
+	for _, segment := range strings.Split(u.Path, "/") {
+		if f, ok := resourceFamilies[segment]; ok {
+			family = f
+			break
+		}
+	}
+
+	return h.limiter.Wait(ctx, family)
+}
+
 func (h *httpHandler) mustBuildURL(path string, opts ...queryOpts) *url.URL {
 	u, _ := url.ParseRequestURI(fmt.Sprintf("%s%s", h.base, path))
 
@@ -79,14 +111,20 @@ func (h *httpHandler) mustBuildURL(path string, opts ...queryOpts) *url.URL {
 	return u
 }
 
-func (h *httpHandler) get(_ context.Context, url *url.URL, model interface{}) error {
-	if h.debug {
-		fmt.Printf("\n-> GET %s t=%d", url.String(), time.Now().Unix())
+func (h *httpHandler) get(ctx context.Context, url *url.URL, model interface{}) error {
+	release, err := h.limit(ctx, url)
+	if err != nil {
+		return err
 	}
+	defer release()
+
+	h.logger.Request(ctx, http.MethodGet, url.String(), nil)
+	start := time.Now()
 
 	// todo use a .Do() method and use the context
 	res, err := h.client.Get(url.String())
 	if err != nil {
+		h.logger.Response(ctx, http.MethodGet, url.String(), "", time.Since(start), nil, err)
 		return err
 	}
 	defer res.Body.Close()
@@ -97,23 +135,19 @@ func (h *httpHandler) get(_ context.Context, url *url.URL, model interface{}) er
 	}
 
 	if res.StatusCode >= http.StatusBadRequest {
-		if h.debug {
-			fmt.Printf("\n<- FAILED GET %s t=%d status=%d - %s", url.String(), res.StatusCode, time.Now().Unix(), body)
-		}
-
 		var httpErr HTTPError
 		err = json.Unmarshal(body, &httpErr)
 		if err != nil {
+			h.logger.Response(ctx, http.MethodGet, url.String(), res.Status, time.Since(start), body, err)
 			return err
 		}
 
 		httpErr.Url = url.String()
+		h.logger.Response(ctx, http.MethodGet, url.String(), res.Status, time.Since(start), body, httpErr)
 		return httpErr
 	}
 
-	if h.debug {
-		fmt.Printf("\n<- GET %s t=%d - %s", url.String(), time.Now().Unix(), body)
-	}
+	h.logger.Response(ctx, http.MethodGet, url.String(), res.Status, time.Since(start), body, nil)
 
 	err = json.Unmarshal(body, &model)
 	if err != nil {
@@ -123,10 +157,15 @@ func (h *httpHandler) get(_ context.Context, url *url.URL, model interface{}) er
 	return nil
 }
 
-func (h *httpHandler) post(_ context.Context, url *url.URL, body []byte, model interface{}) error {
-	if h.debug {
-		fmt.Printf("\n-> POST %s t=%d - %s", url.String(), time.Now().Unix(), string(body))
+func (h *httpHandler) post(ctx context.Context, url *url.URL, body []byte, model interface{}) error {
+	release, err := h.limit(ctx, url)
+	if err != nil {
+		return err
 	}
+	defer release()
+
+	h.logger.Request(ctx, http.MethodPost, url.String(), body)
+	start := time.Now()
 
 	res, err := h.client.Post(
 		url.String(),
@@ -134,6 +173,7 @@ func (h *httpHandler) post(_ context.Context, url *url.URL, body []byte, model i
 		bytes.NewReader(body),
 	)
 	if err != nil {
+		h.logger.Response(ctx, http.MethodPost, url.String(), "", time.Since(start), nil, err)
 		return errors.Wrap(err, fmt.Sprintf("HTTP POST %s failed", url.String()))
 	}
 	defer res.Body.Close()
@@ -144,23 +184,19 @@ func (h *httpHandler) post(_ context.Context, url *url.URL, body []byte, model i
 	}
 
 	if res.StatusCode >= http.StatusBadRequest {
-		if h.debug {
-			fmt.Printf("\n<- POST FAILED %s, status=%d, response: %s", url.String(), res.StatusCode, responseBody)
-		}
-
 		var httpErr HTTPError
 		err = json.Unmarshal(responseBody, &httpErr)
 		if err != nil {
+			h.logger.Response(ctx, http.MethodPost, url.String(), res.Status, time.Since(start), responseBody, err)
 			return err
 		}
 
 		httpErr.Url = url.String()
+		h.logger.Response(ctx, http.MethodPost, url.String(), res.Status, time.Since(start), responseBody, httpErr)
 		return httpErr
 	}
 
-	if h.debug {
-		fmt.Printf("\n<- POST %s t=%d - %s", url.String(), time.Now().Unix(), string(body))
-	}
+	h.logger.Response(ctx, http.MethodPost, url.String(), res.Status, time.Since(start), responseBody, nil)
 
 	err = json.Unmarshal(responseBody, &model)
 	if err != nil {
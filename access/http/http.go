@@ -29,6 +29,7 @@ import (
 	"github.com/onflow/cadence/encoding/json"
 
 	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/access/eventrange"
 	"github.com/onflow/flow-go-sdk/access/http/models"
 
 	"github.com/onflow/cadence"
@@ -150,8 +151,15 @@ func (b *HeightQuery) singleHeightDefined() bool {
 //
 // Use this client if you need advance access to the HTTP API. If you
 // don't require special methods use the Client instead.
-func NewBaseClient(host string) (*BaseClient, error) {
-	handler, err := newHandler(host, false)
+func NewBaseClient(host string, opts ...ClientOption) (*BaseClient, error) {
+	o := options{
+		eventRange: eventrange.DefaultOptions(),
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	handler, err := newHandler(host, o)
 	if err != nil {
 		return nil, err
 	}
@@ -161,6 +169,7 @@ func NewBaseClient(host string) (*BaseClient, error) {
 		jsonOptions: []json.Option{
 			json.WithAllowUnstructuredStaticTypes(true),
 		},
+		eventRange: o.eventRange,
 	}, nil
 }
 
@@ -171,6 +180,7 @@ func NewBaseClient(host string) (*BaseClient, error) {
 type BaseClient struct {
 	handler     handler
 	jsonOptions []json.Option
+	eventRange  eventrange.Options
 }
 
 func (c *BaseClient) SetJSONOptions(options []json.Option) {
@@ -333,6 +343,10 @@ func (c *BaseClient) ExecuteScriptAtBlockHeight(
 	return decodeCadenceValue(result, c.jsonOptions)
 }
 
+// GetEventsForHeightRange gets the events of the given type in the height range.
+//
+// Ranges larger than the access node limit are split and the pieces are fetched concurrently,
+// as configured with the WithEventRangeOptions client option.
 func (c *BaseClient) GetEventsForHeightRange(
 	ctx context.Context,
 	eventType string,
@@ -347,18 +361,38 @@ func (c *BaseClient) GetEventsForHeightRange(
 		return nil, err
 	}
 
-	events, err := c.handler.getEvents(
+	return eventrange.Fetch(
 		ctx,
-		eventType,
-		heightQuery.startString(),
-		heightQuery.endString(),
-		nil,
+		heightQuery.Start,
+		heightQuery.End,
+		c.eventRange,
+		func(ctx context.Context) (uint64, error) {
+			blocks, err := c.GetBlocksByHeights(ctx, HeightQuery{Heights: []uint64{SEALED}})
+			if err != nil {
+				return 0, err
+			}
+			if len(blocks) == 0 {
+				return 0, fmt.Errorf("latest sealed block not found")
+			}
+			return blocks[0].Height, nil
+		},
+		func(ctx context.Context, startHeight uint64, endHeight uint64) ([]flow.BlockEvents, error) {
+			query := HeightQuery{Start: startHeight, End: endHeight}
+
+			events, err := c.handler.getEvents(
+				ctx,
+				eventType,
+				query.startString(),
+				query.endString(),
+				nil,
+			)
+			if err != nil {
+				return nil, err
+			}
+
+			return toBlockEvents(events, c.jsonOptions)
+		},
 	)
-	if err != nil {
-		return nil, err
-	}
-
-	return toBlockEvents(events, c.jsonOptions)
 }
 
 func (c *BaseClient) GetEventsForBlockIDs(
@@ -379,8 +413,14 @@ func (c *BaseClient) GetEventsForBlockIDs(
 	return toBlockEvents(events, c.jsonOptions)
 }
 
+// ErrNotSupported is returned by the methods the REST API does not expose.
+var ErrNotSupported = errors.New("not supported by the HTTP API")
+
+// GetLatestProtocolStateSnapshot is not exposed by the REST API, it always returns an error wrapping ErrNotSupported.
+//
+// Use the gRPC client to retrieve the snapshot, it can be decoded with flow.DecodeProtocolStateSnapshot.
 func (c *BaseClient) GetLatestProtocolStateSnapshot(ctx context.Context) ([]byte, error) {
-	return nil, fmt.Errorf("get latest protocol snapshot is currently not supported for HTTP API, if you require this functionality please open an issue on the flow-go-sdk github")
+	return nil, fmt.Errorf("get latest protocol snapshot: %w", ErrNotSupported)
 }
 
 func (c *BaseClient) GetExecutionResultForBlockID(ctx context.Context, blockID flow.Identifier) (*flow.ExecutionResult, error) {
@@ -393,5 +433,5 @@ func (c *BaseClient) GetExecutionResultForBlockID(ctx context.Context, blockID f
 		return nil, fmt.Errorf("results not found") // sanity check
 	}
 
-	return toExecutionResults(results[0]), nil
+	return toExecutionResults(results[0])
 }
@@ -0,0 +1,55 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2023 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package http
+
+import (
+	"github.com/onflow/flow-go-sdk/access/eventrange"
+	"github.com/onflow/flow-go-sdk/access/logging"
+	"github.com/onflow/flow-go-sdk/access/ratelimit"
+)
+
+// ClientOption configures the HTTP client.
+type ClientOption func(*options)
+
+type options struct {
+	limiter    *ratelimit.Limiter
+	logger     *logging.Hook
+	eventRange eventrange.Options
+}
+
+// WithRateLimiter limits the requests made by the client with the provided limiter.
+func WithRateLimiter(limiter *ratelimit.Limiter) ClientOption {
+	return func(o *options) {
+		o.limiter = limiter
+	}
+}
+
+// WithLogger logs the requests made by the client with the provided hook.
+func WithLogger(hook *logging.Hook) ClientOption {
+	return func(o *options) {
+		o.logger = hook
+	}
+}
+
+// WithEventRangeOptions sets how GetEventsForHeightRange splits ranges exceeding the access node limits.
+func WithEventRangeOptions(eventRange eventrange.Options) ClientOption {
+	return func(o *options) {
+		o.eventRange = eventRange
+	}
+}
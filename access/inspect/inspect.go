@@ -0,0 +1,108 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2023 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package inspect connects the chunks of execution results to the transactions they executed.
+//
+// The execution result of a block only records, for each chunk, the index of the executed
+// collection and its computation. The inspector fetches the block and its collections so each
+// chunk can be mapped to its transactions, aggregates the computation of the block and reports
+// the inconsistencies found in the chunks:
+//
+//	execution, err := inspect.Block(ctx, client, blockID)
+//	for _, chunk := range execution.Chunks {
+//	    fmt.Println(chunk.CollectionID, chunk.Chunk.TotalComputationUsed, len(chunk.TransactionIDs))
+//	}
+package inspect
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/access"
+)
+
+// Chunk is a chunk of an execution result with the transactions it executed.
+type Chunk struct {
+	flow.ChunkCollection
+	// TransactionIDs are the transactions of the executed collection, empty for the system chunk.
+	TransactionIDs []flow.Identifier
+}
+
+// BlockExecution describes the execution of a block.
+type BlockExecution struct {
+	Block  *flow.Block
+	Result *flow.ExecutionResult
+	// Chunks are the chunks of the execution result, in order.
+	Chunks []Chunk
+	// Computation aggregates the computation used by the chunks.
+	Computation flow.ComputationSummary
+	// Anomalies are the inconsistencies found in the chunks.
+	Anomalies []flow.ChunkAnomaly
+}
+
+// Block fetches the block, its execution result and its collections and maps each chunk to its transactions.
+func Block(ctx context.Context, client access.Client, blockID flow.Identifier) (*BlockExecution, error) {
+	block, err := client.GetBlockByID(ctx, blockID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block %s: %w", blockID, err)
+	}
+
+	result, err := client.GetExecutionResultForBlockID(ctx, blockID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get execution result of block %s: %w", blockID, err)
+	}
+
+	collections, anomalies := result.ChunkCollections(*block)
+	anomalies = append(anomalies, result.ChunkAnomalies()...)
+
+	chunks := make([]Chunk, len(collections))
+	for i, c := range collections {
+		chunks[i] = Chunk{ChunkCollection: c}
+		if c.SystemChunk || c.CollectionID == flow.EmptyID {
+			continue
+		}
+
+		collection, err := client.GetCollection(ctx, c.CollectionID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get collection %s: %w", c.CollectionID, err)
+		}
+		chunks[i].TransactionIDs = collection.TransactionIDs
+
+		if len(collection.TransactionIDs) != int(c.Chunk.NumberOfTransactions) {
+			anomalies = append(anomalies, flow.ChunkAnomaly{
+				ChunkIndex: i,
+				Kind:       flow.ChunkAnomalyTransactionCount,
+				Message: fmt.Sprintf(
+					"chunk executed %d transactions but collection %s has %d",
+					c.Chunk.NumberOfTransactions,
+					c.CollectionID,
+					len(collection.TransactionIDs),
+				),
+			})
+		}
+	}
+
+	return &BlockExecution{
+		Block:       block,
+		Result:      result,
+		Chunks:      chunks,
+		Computation: result.ComputationSummary(),
+		Anomalies:   anomalies,
+	}, nil
+}
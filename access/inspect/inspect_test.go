@@ -0,0 +1,90 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2023 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package inspect
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/access"
+)
+
+// fakeClient implements the methods used by the tests, any other method panics.
+type fakeClient struct {
+	access.Client
+	block       *flow.Block
+	result      *flow.ExecutionResult
+	collections map[flow.Identifier]*flow.Collection
+}
+
+func (f *fakeClient) GetBlockByID(_ context.Context, _ flow.Identifier) (*flow.Block, error) {
+	return f.block, nil
+}
+
+func (f *fakeClient) GetExecutionResultForBlockID(_ context.Context, _ flow.Identifier) (*flow.ExecutionResult, error) {
+	return f.result, nil
+}
+
+func (f *fakeClient) GetCollection(_ context.Context, colID flow.Identifier) (*flow.Collection, error) {
+	return f.collections[colID], nil
+}
+
+func TestBlock(t *testing.T) {
+	blockID := flow.Identifier{9}
+	colID := flow.Identifier{1}
+
+	client := &fakeClient{
+		block: &flow.Block{
+			BlockHeader: flow.BlockHeader{ID: blockID},
+			BlockPayload: flow.BlockPayload{
+				CollectionGuarantees: []*flow.CollectionGuarantee{{CollectionID: colID}},
+			},
+		},
+		result: &flow.ExecutionResult{
+			BlockID: blockID,
+			Chunks: []*flow.Chunk{
+				{CollectionIndex: 0, Index: 0, BlockID: blockID, EndState: flow.StateCommitment{1}, TotalComputationUsed: 10, NumberOfTransactions: 3},
+				{CollectionIndex: 1, Index: 1, BlockID: blockID, StartState: flow.StateCommitment{1}, TotalComputationUsed: 2, NumberOfTransactions: 1},
+			},
+		},
+		collections: map[flow.Identifier]*flow.Collection{
+			colID: {TransactionIDs: []flow.Identifier{{0xa}, {0xb}}},
+		},
+	}
+
+	execution, err := Block(context.Background(), client, blockID)
+	require.NoError(t, err)
+
+	require.Len(t, execution.Chunks, 2)
+	assert.Equal(t, colID, execution.Chunks[0].CollectionID)
+	assert.Equal(t, []flow.Identifier{{0xa}, {0xb}}, execution.Chunks[0].TransactionIDs)
+	assert.True(t, execution.Chunks[1].SystemChunk)
+	assert.Empty(t, execution.Chunks[1].TransactionIDs)
+
+	assert.Equal(t, uint64(12), execution.Computation.TotalComputationUsed)
+
+	// the chunk executed 3 transactions but the collection only has 2
+	require.Len(t, execution.Anomalies, 1)
+	assert.Equal(t, flow.ChunkAnomalyTransactionCount, execution.Anomalies[0].Kind)
+	assert.Equal(t, 0, execution.Anomalies[0].ChunkIndex)
+}
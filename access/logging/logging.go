@@ -0,0 +1,232 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2023 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package logging provides a structured logging hook shared by the access clients.
+//
+// The hook writes to a Logger provided by the caller, which is satisfied by *slog.Logger,
+// so nothing is written to stdout unless the application decides so. Request and response
+// bodies are only logged at LevelBodies, are truncated to a maximum size and have their
+// signatures and arguments redacted:
+//
+//	logger := slog.New(slog.NewJSONHandler(os.Stderr, nil))
+//	hook := logging.NewHook(logger, logging.WithLevel(logging.LevelBodies))
+//	client, err := http.NewClient(http.MainnetHost, http.WithLogger(hook))
+package logging
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// Logger is the structured logger the hook writes to.
+//
+// The method set matches *slog.Logger, any logger exposing the same methods can be used.
+type Logger interface {
+	DebugContext(ctx context.Context, msg string, args ...any)
+	InfoContext(ctx context.Context, msg string, args ...any)
+	WarnContext(ctx context.Context, msg string, args ...any)
+}
+
+// Level defines what the hook logs.
+type Level int
+
+const (
+	// LevelNone disables logging.
+	LevelNone Level = iota
+	// LevelRequests logs the method, target, status and duration of each request.
+	LevelRequests
+	// LevelBodies additionally logs the request and response bodies.
+	LevelBodies
+)
+
+// DefaultMaxBodySize is the default number of bytes of a body included in a log entry.
+const DefaultMaxBodySize = 1024
+
+// DefaultRedactedFields are the body fields redacted by default.
+var DefaultRedactedFields = []string{
+	"arguments",
+	"signature",
+	"payload_signatures",
+	"envelope_signatures",
+	"payloadSignatures",
+	"envelopeSignatures",
+}
+
+const (
+	redacted = "[REDACTED]"
+	// unparseableBody replaces the bodies which cannot be redacted, as they may still contain
+	// the redacted fields, such as truncated JSON bodies.
+	unparseableBody = "(unparseable body redacted)"
+)
+
+// Option configures the hook.
+type Option func(*Hook)
+
+// WithLevel sets the logging level, LevelRequests is used by default.
+func WithLevel(level Level) Option {
+	return func(h *Hook) {
+		h.level = level
+	}
+}
+
+// WithMaxBodySize sets the maximum number of bytes of a body included in a log entry.
+//
+// A size of zero or less disables the truncation.
+func WithMaxBodySize(size int) Option {
+	return func(h *Hook) {
+		h.maxBodySize = size
+	}
+}
+
+// WithRedactedFields replaces the body fields which values are redacted.
+func WithRedactedFields(fields ...string) Option {
+	return func(h *Hook) {
+		h.redacted = toSet(fields)
+	}
+}
+
+// Hook logs the requests made by the access clients.
+//
+// A nil hook is valid and logs nothing.
+type Hook struct {
+	logger      Logger
+	level       Level
+	maxBodySize int
+	redacted    map[string]struct{}
+}
+
+// NewHook creates a hook writing to the provided logger.
+func NewHook(logger Logger, opts ...Option) *Hook {
+	h := &Hook{
+		logger:      logger,
+		level:       LevelRequests,
+		maxBodySize: DefaultMaxBodySize,
+		redacted:    toSet(DefaultRedactedFields),
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+func toSet(fields []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(fields))
+	for _, f := range fields {
+		set[f] = struct{}{}
+	}
+	return set
+}
+
+// Enabled reports whether the hook logs anything at the provided level.
+func (h *Hook) Enabled(level Level) bool {
+	return h != nil && h.logger != nil && level != LevelNone && h.level >= level
+}
+
+// Request logs a request before it is sent.
+//
+// The method is the RPC method or HTTP verb, the target is the RPC service or the URL.
+func (h *Hook) Request(ctx context.Context, method string, target string, body []byte) {
+	if !h.Enabled(LevelRequests) {
+		return
+	}
+
+	args := []any{"method", method, "target", target}
+	if h.Enabled(LevelBodies) && len(body) > 0 {
+		args = append(args, "body", h.Body(body))
+	}
+
+	h.logger.DebugContext(ctx, "access request", args...)
+}
+
+// Response logs the outcome of a request.
+//
+// Failed requests are logged as warnings.
+func (h *Hook) Response(
+	ctx context.Context,
+	method string,
+	target string,
+	status string,
+	duration time.Duration,
+	body []byte,
+	err error,
+) {
+	if !h.Enabled(LevelRequests) {
+		return
+	}
+
+	args := []any{"method", method, "target", target, "status", status, "duration", duration}
+	if h.Enabled(LevelBodies) && len(body) > 0 {
+		args = append(args, "body", h.Body(body))
+	}
+
+	if err != nil {
+		args = append(args, "error", err.Error())
+		h.logger.WarnContext(ctx, "access request failed", args...)
+		return
+	}
+
+	h.logger.DebugContext(ctx, "access response", args...)
+}
+
+// Body returns the body as it is included in the log entries, redacted and truncated.
+func (h *Hook) Body(body []byte) string {
+	body = h.redact(body)
+	if h.maxBodySize > 0 && len(body) > h.maxBodySize {
+		return string(body[:h.maxBodySize]) + "...(truncated)"
+	}
+	return string(body)
+}
+
+// redact replaces the values of the redacted fields in a JSON body.
+//
+// Bodies that are not valid JSON are replaced by a placeholder when fields are redacted.
+func (h *Hook) redact(body []byte) []byte {
+	if len(h.redacted) == 0 {
+		return body
+	}
+
+	var value interface{}
+	if err := json.Unmarshal(body, &value); err != nil {
+		return []byte(unparseableBody)
+	}
+
+	redactedBody, err := json.Marshal(h.redactValue(value))
+	if err != nil {
+		return []byte(unparseableBody)
+	}
+	return redactedBody
+}
+
+func (h *Hook) redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, field := range v {
+			if _, ok := h.redacted[key]; ok {
+				v[key] = redacted
+				continue
+			}
+			v[key] = h.redactValue(field)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = h.redactValue(item)
+		}
+	}
+	return value
+}
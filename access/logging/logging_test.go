@@ -0,0 +1,156 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2023 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package logging_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk/access/logging"
+)
+
+type entry struct {
+	level string
+	msg   string
+	args  map[string]any
+}
+
+// fakeLogger records the log entries.
+type fakeLogger struct {
+	entries []entry
+}
+
+func (f *fakeLogger) log(level string, msg string, args []any) {
+	e := entry{level: level, msg: msg, args: map[string]any{}}
+	for i := 0; i+1 < len(args); i += 2 {
+		e.args[fmt.Sprint(args[i])] = args[i+1]
+	}
+	f.entries = append(f.entries, e)
+}
+
+func (f *fakeLogger) DebugContext(_ context.Context, msg string, args ...any) {
+	f.log("debug", msg, args)
+}
+
+func (f *fakeLogger) InfoContext(_ context.Context, msg string, args ...any) {
+	f.log("info", msg, args)
+}
+
+func (f *fakeLogger) WarnContext(_ context.Context, msg string, args ...any) {
+	f.log("warn", msg, args)
+}
+
+func TestHook_Levels(t *testing.T) {
+	ctx := context.Background()
+	body := []byte(`{"script":"abc"}`)
+
+	t.Run("None", func(t *testing.T) {
+		logger := &fakeLogger{}
+		hook := logging.NewHook(logger, logging.WithLevel(logging.LevelNone))
+
+		hook.Request(ctx, "POST", "/scripts", body)
+		assert.Empty(t, logger.entries)
+	})
+
+	t.Run("Requests", func(t *testing.T) {
+		logger := &fakeLogger{}
+		hook := logging.NewHook(logger)
+
+		hook.Request(ctx, "POST", "/scripts", body)
+		hook.Response(ctx, "POST", "/scripts", "200 OK", time.Second, body, nil)
+
+		require.Len(t, logger.entries, 2)
+		assert.Equal(t, "/scripts", logger.entries[0].args["target"])
+		assert.NotContains(t, logger.entries[0].args, "body")
+		assert.Equal(t, "200 OK", logger.entries[1].args["status"])
+		assert.NotContains(t, logger.entries[1].args, "body")
+	})
+
+	t.Run("Bodies", func(t *testing.T) {
+		logger := &fakeLogger{}
+		hook := logging.NewHook(logger, logging.WithLevel(logging.LevelBodies))
+
+		hook.Request(ctx, "POST", "/scripts", body)
+
+		require.Len(t, logger.entries, 1)
+		assert.Equal(t, string(body), logger.entries[0].args["body"])
+	})
+
+	t.Run("Failure", func(t *testing.T) {
+		logger := &fakeLogger{}
+		hook := logging.NewHook(logger)
+
+		hook.Response(ctx, "GET", "/blocks", "500", time.Second, nil, errors.New("internal"))
+
+		require.Len(t, logger.entries, 1)
+		assert.Equal(t, "warn", logger.entries[0].level)
+		assert.Equal(t, "internal", logger.entries[0].args["error"])
+	})
+
+	t.Run("Nil", func(t *testing.T) {
+		var hook *logging.Hook
+		hook.Request(ctx, "GET", "/blocks", nil)
+		assert.False(t, hook.Enabled(logging.LevelRequests))
+	})
+}
+
+func TestHook_Body(t *testing.T) {
+	t.Run("Redaction", func(t *testing.T) {
+		hook := logging.NewHook(&fakeLogger{})
+
+		body := hook.Body([]byte(`{"script":"abc","arguments":["x"],"envelope_signatures":[{"signature":"sig"}]}`))
+		assert.JSONEq(t, `{"script":"abc","arguments":"[REDACTED]","envelope_signatures":"[REDACTED]"}`, body)
+
+		body = hook.Body([]byte(`{"keys":[{"signature":"sig","index":"1"}]}`))
+		assert.JSONEq(t, `{"keys":[{"signature":"[REDACTED]","index":"1"}]}`, body)
+	})
+
+	t.Run("Custom redaction", func(t *testing.T) {
+		hook := logging.NewHook(&fakeLogger{}, logging.WithRedactedFields("script"))
+
+		body := hook.Body([]byte(`{"script":"abc","arguments":["x"]}`))
+		assert.JSONEq(t, `{"script":"[REDACTED]","arguments":["x"]}`, body)
+	})
+
+	t.Run("Unparseable body", func(t *testing.T) {
+		hook := logging.NewHook(&fakeLogger{})
+
+		body := hook.Body([]byte(`{"script":"abc","arguments":["secret"`))
+		assert.Equal(t, "(unparseable body redacted)", body)
+
+		// bodies are logged as is when nothing is redacted
+		hook = logging.NewHook(&fakeLogger{}, logging.WithRedactedFields())
+		body = hook.Body([]byte(`{"arguments":["secret"`))
+		assert.Equal(t, `{"arguments":["secret"`, body)
+	})
+
+	t.Run("Truncation", func(t *testing.T) {
+		hook := logging.NewHook(&fakeLogger{}, logging.WithMaxBodySize(8))
+
+		body := hook.Body([]byte(`"` + strings.Repeat("a", 20) + `"`))
+		assert.Equal(t, `"`+strings.Repeat("a", 7)+"...(truncated)", body)
+	})
+}
@@ -0,0 +1,662 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2023 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package multi provides an access client that spreads requests over several access nodes.
+//
+// The client wraps any number of access.Client implementations, which can freely mix
+// the gRPC and HTTP transports. Requests are routed either round-robin or to the endpoint
+// with the lowest observed latency, and a failing endpoint is skipped in favour of the
+// next one, so a single flapping access node no longer takes the caller down with it.
+package multi
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/onflow/cadence"
+	jsoncdc "github.com/onflow/cadence/encoding/json"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/access"
+)
+
+// Strategy defines the order in which endpoints are tried for a request.
+type Strategy int
+
+const (
+	// RoundRobin rotates the first endpoint tried on every request.
+	RoundRobin Strategy = iota
+	// LowestLatency tries endpoints ordered by their observed average latency.
+	LowestLatency
+)
+
+// DefaultHealthCheckInterval is the interval at which endpoints are pinged by default.
+const DefaultHealthCheckInterval = 10 * time.Second
+
+// latencyWeight is the weight given to a new latency sample in the moving average.
+const latencyWeight = 0.2
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithStrategy sets the routing strategy, RoundRobin is used by default.
+func WithStrategy(strategy Strategy) Option {
+	return func(c *Client) {
+		c.strategy = strategy
+	}
+}
+
+// WithHealthCheckInterval sets the interval at which all endpoints are pinged.
+//
+// A zero or negative interval disables the background health checks, endpoints
+// are then only marked as unhealthy by failing requests.
+func WithHealthCheckInterval(interval time.Duration) Option {
+	return func(c *Client) {
+		c.healthCheckInterval = interval
+	}
+}
+
+// WithFailoverPolicy sets the function deciding whether an error returned by an
+// endpoint should cause the request to be retried on the next endpoint.
+func WithFailoverPolicy(shouldFailover func(error) bool) Option {
+	return func(c *Client) {
+		c.shouldFailover = shouldFailover
+	}
+}
+
+// WithScriptConsistencyCheck enables the "read from two and compare" mode for scripts.
+//
+// When enabled, every script is executed on two different endpoints and the results
+// are compared, an InconsistentResultError is returned if they differ.
+func WithScriptConsistencyCheck() Option {
+	return func(c *Client) {
+		c.compareScripts = true
+	}
+}
+
+// ErrNoEndpoints is returned when a client is created without any endpoints.
+var ErrNoEndpoints = errors.New("at least one endpoint must be provided")
+
+// An InconsistentResultError is returned when two endpoints return different results for the same script.
+type InconsistentResultError struct {
+	// Endpoints are the indices of the endpoints which returned the results.
+	Endpoints [2]int
+	// Results are the values returned by the endpoints.
+	Results [2]cadence.Value
+}
+
+func (e InconsistentResultError) Error() string {
+	return fmt.Sprintf(
+		"script results from endpoint %d and endpoint %d do not match: %s != %s",
+		e.Endpoints[0], e.Endpoints[1], e.Results[0], e.Results[1],
+	)
+}
+
+// An EndpointsError is returned when a request failed on every endpoint it was tried on.
+type EndpointsError struct {
+	// Errs contains the error returned by each endpoint in the order they were tried.
+	Errs []error
+}
+
+func (e EndpointsError) Error() string {
+	return fmt.Sprintf("request failed on all %d endpoints, last error: %s", len(e.Errs), e.Unwrap())
+}
+
+// Unwrap returns the error returned by the last endpoint tried.
+func (e EndpointsError) Unwrap() error {
+	return e.Errs[len(e.Errs)-1]
+}
+
+// EndpointStatus describes the observed state of an endpoint.
+type EndpointStatus struct {
+	// Index is the position of the endpoint in the list passed to NewClient.
+	Index int
+	// Healthy reports whether the last request or health check succeeded.
+	Healthy bool
+	// Latency is the moving average of the observed request latency.
+	Latency time.Duration
+	// Failures is the number of failed requests since the last successful one.
+	Failures int
+	// LastError is the last error returned by the endpoint.
+	LastError error
+}
+
+type endpoint struct {
+	index    int
+	client   access.Client
+	healthy  bool
+	latency  time.Duration
+	failures int
+	lastErr  error
+}
+
+// Client implements access.Client on top of several access.Client endpoints.
+type Client struct {
+	endpoints           []*endpoint
+	strategy            Strategy
+	healthCheckInterval time.Duration
+	shouldFailover      func(error) bool
+	compareScripts      bool
+
+	mu   sync.RWMutex
+	next int
+
+	done      chan struct{}
+	wg        sync.WaitGroup
+	closeOnce sync.Once
+	closeErr  error
+}
+
+var _ access.Client = (*Client)(nil)
+
+// NewClient creates a client routing requests over the provided endpoints.
+//
+// Unless disabled with WithHealthCheckInterval, a background health check pings every
+// endpoint periodically until the client is closed.
+func NewClient(clients []access.Client, opts ...Option) (*Client, error) {
+	if len(clients) == 0 {
+		return nil, ErrNoEndpoints
+	}
+
+	endpoints := make([]*endpoint, len(clients))
+	for i, client := range clients {
+		endpoints[i] = &endpoint{
+			index:   i,
+			client:  client,
+			healthy: true,
+		}
+	}
+
+	c := &Client{
+		endpoints:           endpoints,
+		strategy:            RoundRobin,
+		healthCheckInterval: DefaultHealthCheckInterval,
+		shouldFailover:      ShouldFailover,
+		done:                make(chan struct{}),
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	if c.healthCheckInterval > 0 {
+		c.wg.Add(1)
+		go c.healthCheckLoop()
+	}
+
+	return c, nil
+}
+
+// Status returns the observed state of every endpoint.
+func (c *Client) Status() []EndpointStatus {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	statuses := make([]EndpointStatus, len(c.endpoints))
+	for i, e := range c.endpoints {
+		statuses[i] = EndpointStatus{
+			Index:     e.index,
+			Healthy:   e.healthy,
+			Latency:   e.latency,
+			Failures:  e.failures,
+			LastError: e.lastErr,
+		}
+	}
+
+	return statuses
+}
+
+// CheckHealth pings all the endpoints and updates their health status.
+func (c *Client) CheckHealth(ctx context.Context) {
+	var wg sync.WaitGroup
+	for _, e := range c.endpoints {
+		wg.Add(1)
+		go func(e *endpoint) {
+			defer wg.Done()
+			start := time.Now()
+			err := e.client.Ping(ctx)
+			c.record(e, time.Since(start), err)
+		}(e)
+	}
+	wg.Wait()
+}
+
+func (c *Client) healthCheckLoop() {
+	defer c.wg.Done()
+
+	ticker := time.NewTicker(c.healthCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.done:
+			return
+		case <-ticker.C:
+			ctx, cancel := context.WithTimeout(context.Background(), c.healthCheckInterval)
+			c.CheckHealth(ctx)
+			cancel()
+		}
+	}
+}
+
+// record updates the endpoint state with the outcome of a request.
+func (c *Client) record(e *endpoint, latency time.Duration, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err != nil {
+		e.healthy = false
+		e.failures++
+		e.lastErr = err
+		return
+	}
+
+	e.healthy = true
+	e.failures = 0
+	if e.latency == 0 {
+		e.latency = latency
+	} else {
+		e.latency = time.Duration(latencyWeight*float64(latency) + (1-latencyWeight)*float64(e.latency))
+	}
+}
+
+// order returns the endpoints in the order they should be tried for the next request.
+//
+// Healthy endpoints always come before unhealthy ones, so that unhealthy endpoints
+// are still used as a last resort when every endpoint is failing.
+func (c *Client) order() []*endpoint {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	n := len(c.endpoints)
+	ordered := make([]*endpoint, 0, n)
+
+	switch c.strategy {
+	case LowestLatency:
+		ordered = append(ordered, c.endpoints...)
+		// insertion sort, the endpoint list is expected to be small
+		for i := 1; i < n; i++ {
+			for j := i; j > 0 && ordered[j].latency < ordered[j-1].latency; j-- {
+				ordered[j], ordered[j-1] = ordered[j-1], ordered[j]
+			}
+		}
+	default:
+		start := c.next
+		c.next = (c.next + 1) % n
+		for i := 0; i < n; i++ {
+			ordered = append(ordered, c.endpoints[(start+i)%n])
+		}
+	}
+
+	healthy := make([]*endpoint, 0, n)
+	unhealthy := make([]*endpoint, 0, n)
+	for _, e := range ordered {
+		if e.healthy {
+			healthy = append(healthy, e)
+		} else {
+			unhealthy = append(unhealthy, e)
+		}
+	}
+
+	return append(healthy, unhealthy...)
+}
+
+// do executes the request on the endpoints in order until one of them succeeds.
+func (c *Client) do(ctx context.Context, request func(client access.Client) error) error {
+	_, err := c.doFrom(ctx, c.order(), request)
+	return err
+}
+
+// doFrom executes the request on the given endpoints in order until one of them
+// succeeds and returns the endpoint that served the request.
+func (c *Client) doFrom(
+	ctx context.Context,
+	endpoints []*endpoint,
+	request func(client access.Client) error,
+) (*endpoint, error) {
+	errs := make([]error, 0, len(endpoints))
+
+	for _, e := range endpoints {
+		start := time.Now()
+		err := request(e.client)
+		if err == nil {
+			c.record(e, time.Since(start), nil)
+			return e, nil
+		}
+
+		if ctx.Err() != nil {
+			// the request was cut short by the caller, which tells nothing about the endpoint
+			return e, err
+		}
+
+		if !c.shouldFailover(err) {
+			// the error is caused by the request itself, the endpoint is fine
+			c.record(e, time.Since(start), nil)
+			return e, err
+		}
+
+		c.record(e, time.Since(start), err)
+		errs = append(errs, err)
+	}
+
+	return nil, EndpointsError{Errs: errs}
+}
+
+// executeScript runs the script request, either on a single endpoint or on two
+// endpoints and compares the results if the consistency check is enabled.
+func (c *Client) executeScript(
+	ctx context.Context,
+	request func(client access.Client) (cadence.Value, error),
+) (cadence.Value, error) {
+	if !c.compareScripts || len(c.endpoints) < 2 {
+		var result cadence.Value
+		err := c.do(ctx, func(client access.Client) error {
+			var err error
+			result, err = request(client)
+			return err
+		})
+		return result, err
+	}
+
+	var results [2]cadence.Value
+	var served [2]*endpoint
+
+	remaining := c.order()
+	for i := range results {
+		e, err := c.doFrom(ctx, remaining, func(client access.Client) error {
+			var err error
+			results[i], err = request(client)
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+		served[i] = e
+
+		// the second read must come from a different endpoint than the first one
+		for j, r := range remaining {
+			if r == e {
+				remaining = append(remaining[:j:j], remaining[j+1:]...)
+				break
+			}
+		}
+		if len(remaining) == 0 && i == 0 {
+			return nil, fmt.Errorf("no endpoint left to compare the script result with")
+		}
+	}
+
+	if !cadenceValuesEqual(results[0], results[1]) {
+		return nil, InconsistentResultError{
+			Endpoints: [2]int{served[0].index, served[1].index},
+			Results:   results,
+		}
+	}
+
+	return results[0], nil
+}
+
+// cadenceValuesEqual compares two values by their JSON-CDC encoding.
+func cadenceValuesEqual(a, b cadence.Value) bool {
+	encodedA, err := jsoncdc.Encode(a)
+	if err != nil {
+		return false
+	}
+
+	encodedB, err := jsoncdc.Encode(b)
+	if err != nil {
+		return false
+	}
+
+	return bytes.Equal(encodedA, encodedB)
+}
+
+func (c *Client) Ping(ctx context.Context) error {
+	return c.do(ctx, func(client access.Client) error {
+		return client.Ping(ctx)
+	})
+}
+
+func (c *Client) GetLatestBlockHeader(ctx context.Context, isSealed bool) (*flow.BlockHeader, error) {
+	var header *flow.BlockHeader
+	err := c.do(ctx, func(client access.Client) error {
+		var err error
+		header, err = client.GetLatestBlockHeader(ctx, isSealed)
+		return err
+	})
+	return header, err
+}
+
+func (c *Client) GetBlockHeaderByID(ctx context.Context, blockID flow.Identifier) (*flow.BlockHeader, error) {
+	var header *flow.BlockHeader
+	err := c.do(ctx, func(client access.Client) error {
+		var err error
+		header, err = client.GetBlockHeaderByID(ctx, blockID)
+		return err
+	})
+	return header, err
+}
+
+func (c *Client) GetBlockHeaderByHeight(ctx context.Context, height uint64) (*flow.BlockHeader, error) {
+	var header *flow.BlockHeader
+	err := c.do(ctx, func(client access.Client) error {
+		var err error
+		header, err = client.GetBlockHeaderByHeight(ctx, height)
+		return err
+	})
+	return header, err
+}
+
+func (c *Client) GetLatestBlock(ctx context.Context, isSealed bool) (*flow.Block, error) {
+	var block *flow.Block
+	err := c.do(ctx, func(client access.Client) error {
+		var err error
+		block, err = client.GetLatestBlock(ctx, isSealed)
+		return err
+	})
+	return block, err
+}
+
+func (c *Client) GetBlockByID(ctx context.Context, blockID flow.Identifier) (*flow.Block, error) {
+	var block *flow.Block
+	err := c.do(ctx, func(client access.Client) error {
+		var err error
+		block, err = client.GetBlockByID(ctx, blockID)
+		return err
+	})
+	return block, err
+}
+
+func (c *Client) GetBlockByHeight(ctx context.Context, height uint64) (*flow.Block, error) {
+	var block *flow.Block
+	err := c.do(ctx, func(client access.Client) error {
+		var err error
+		block, err = client.GetBlockByHeight(ctx, height)
+		return err
+	})
+	return block, err
+}
+
+func (c *Client) GetCollection(ctx context.Context, colID flow.Identifier) (*flow.Collection, error) {
+	var collection *flow.Collection
+	err := c.do(ctx, func(client access.Client) error {
+		var err error
+		collection, err = client.GetCollection(ctx, colID)
+		return err
+	})
+	return collection, err
+}
+
+func (c *Client) SendTransaction(ctx context.Context, tx flow.Transaction) error {
+	// resubmitting a transaction to another node is safe, duplicates are discarded by the network
+	return c.do(ctx, func(client access.Client) error {
+		return client.SendTransaction(ctx, tx)
+	})
+}
+
+func (c *Client) GetTransaction(ctx context.Context, txID flow.Identifier) (*flow.Transaction, error) {
+	var tx *flow.Transaction
+	err := c.do(ctx, func(client access.Client) error {
+		var err error
+		tx, err = client.GetTransaction(ctx, txID)
+		return err
+	})
+	return tx, err
+}
+
+func (c *Client) GetTransactionsByBlockID(ctx context.Context, blockID flow.Identifier) ([]*flow.Transaction, error) {
+	var txs []*flow.Transaction
+	err := c.do(ctx, func(client access.Client) error {
+		var err error
+		txs, err = client.GetTransactionsByBlockID(ctx, blockID)
+		return err
+	})
+	return txs, err
+}
+
+func (c *Client) GetTransactionResult(ctx context.Context, txID flow.Identifier) (*flow.TransactionResult, error) {
+	var result *flow.TransactionResult
+	err := c.do(ctx, func(client access.Client) error {
+		var err error
+		result, err = client.GetTransactionResult(ctx, txID)
+		return err
+	})
+	return result, err
+}
+
+func (c *Client) GetTransactionResultsByBlockID(ctx context.Context, blockID flow.Identifier) ([]*flow.TransactionResult, error) {
+	var results []*flow.TransactionResult
+	err := c.do(ctx, func(client access.Client) error {
+		var err error
+		results, err = client.GetTransactionResultsByBlockID(ctx, blockID)
+		return err
+	})
+	return results, err
+}
+
+func (c *Client) ExecuteScriptAtLatestBlock(
+	ctx context.Context,
+	script []byte,
+	arguments []cadence.Value,
+) (cadence.Value, error) {
+	return c.executeScript(ctx, func(client access.Client) (cadence.Value, error) {
+		return client.ExecuteScriptAtLatestBlock(ctx, script, arguments)
+	})
+}
+
+func (c *Client) ExecuteScriptAtBlockID(
+	ctx context.Context,
+	blockID flow.Identifier,
+	script []byte,
+	arguments []cadence.Value,
+) (cadence.Value, error) {
+	return c.executeScript(ctx, func(client access.Client) (cadence.Value, error) {
+		return client.ExecuteScriptAtBlockID(ctx, blockID, script, arguments)
+	})
+}
+
+func (c *Client) ExecuteScriptAtBlockHeight(
+	ctx context.Context,
+	height uint64,
+	script []byte,
+	arguments []cadence.Value,
+) (cadence.Value, error) {
+	return c.executeScript(ctx, func(client access.Client) (cadence.Value, error) {
+		return client.ExecuteScriptAtBlockHeight(ctx, height, script, arguments)
+	})
+}
+
+func (c *Client) GetEventsForHeightRange(
+	ctx context.Context,
+	eventType string,
+	startHeight uint64,
+	endHeight uint64,
+) ([]flow.BlockEvents, error) {
+	var events []flow.BlockEvents
+	err := c.do(ctx, func(client access.Client) error {
+		var err error
+		events, err = client.GetEventsForHeightRange(ctx, eventType, startHeight, endHeight)
+		return err
+	})
+	return events, err
+}
+
+func (c *Client) GetEventsForBlockIDs(
+	ctx context.Context,
+	eventType string,
+	blockIDs []flow.Identifier,
+) ([]flow.BlockEvents, error) {
+	var events []flow.BlockEvents
+	err := c.do(ctx, func(client access.Client) error {
+		var err error
+		events, err = client.GetEventsForBlockIDs(ctx, eventType, blockIDs)
+		return err
+	})
+	return events, err
+}
+
+func (c *Client) GetLatestProtocolStateSnapshot(ctx context.Context) ([]byte, error) {
+	var snapshot []byte
+	err := c.do(ctx, func(client access.Client) error {
+		var err error
+		snapshot, err = client.GetLatestProtocolStateSnapshot(ctx)
+		return err
+	})
+	return snapshot, err
+}
+
+func (c *Client) GetExecutionResultForBlockID(ctx context.Context, blockID flow.Identifier) (*flow.ExecutionResult, error) {
+	var result *flow.ExecutionResult
+	err := c.do(ctx, func(client access.Client) error {
+		var err error
+		result, err = client.GetExecutionResultForBlockID(ctx, blockID)
+		return err
+	})
+	return result, err
+}
+
+// Close stops the health checks and closes all the endpoints.
+//
+// Closing the client more than once returns the result of the first call.
+func (c *Client) Close() error {
+	c.closeOnce.Do(func() {
+		c.closeErr = c.close()
+	})
+	return c.closeErr
+}
+
+func (c *Client) close() error {
+	close(c.done)
+	c.wg.Wait()
+
+	var errs []error
+	for _, e := range c.endpoints {
+		if err := e.client.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if len(errs) > 0 {
+		return EndpointsError{Errs: errs}
+	}
+
+	return nil
+}
@@ -0,0 +1,191 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2023 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package multi
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/onflow/cadence"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/access"
+)
+
+// fakeClient implements the methods used by the tests, any other method panics.
+type fakeClient struct {
+	access.Client
+	height uint64
+	err    error
+	value  cadence.Value
+	calls  int
+}
+
+func (f *fakeClient) GetLatestBlockHeader(_ context.Context, _ bool) (*flow.BlockHeader, error) {
+	f.calls++
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &flow.BlockHeader{Height: f.height}, nil
+}
+
+func (f *fakeClient) ExecuteScriptAtLatestBlock(_ context.Context, _ []byte, _ []cadence.Value) (cadence.Value, error) {
+	f.calls++
+	return f.value, f.err
+}
+
+func (f *fakeClient) Close() error {
+	return nil
+}
+
+func newTestClient(t *testing.T, clients []*fakeClient, opts ...Option) *Client {
+	endpoints := make([]access.Client, len(clients))
+	for i, c := range clients {
+		endpoints[i] = c
+	}
+
+	opts = append(opts, WithHealthCheckInterval(0))
+	client, err := NewClient(endpoints, opts...)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = client.Close() })
+
+	return client
+}
+
+func TestClient_Failover(t *testing.T) {
+	failing := &fakeClient{err: status.Error(codes.Unavailable, "node down")}
+	working := &fakeClient{height: 42}
+
+	client := newTestClient(t, []*fakeClient{failing, working})
+
+	header, err := client.GetLatestBlockHeader(context.Background(), true)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(42), header.Height)
+
+	statuses := client.Status()
+	assert.False(t, statuses[0].Healthy)
+	assert.Equal(t, 1, statuses[0].Failures)
+	assert.True(t, statuses[1].Healthy)
+
+	// the unhealthy endpoint is moved to the back of the queue
+	_, err = client.GetLatestBlockHeader(context.Background(), true)
+	require.NoError(t, err)
+	assert.Equal(t, 1, failing.calls)
+	assert.Equal(t, 2, working.calls)
+}
+
+func TestClient_NoFailoverOnRequestError(t *testing.T) {
+	notFound := &fakeClient{err: status.Error(codes.NotFound, "not found")}
+	working := &fakeClient{height: 42}
+
+	client := newTestClient(t, []*fakeClient{notFound, working})
+
+	_, err := client.GetLatestBlockHeader(context.Background(), true)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	assert.Equal(t, 0, working.calls)
+	assert.True(t, client.Status()[0].Healthy)
+}
+
+func TestClient_ContextErrorNotRecorded(t *testing.T) {
+	hanging := &fakeClient{err: status.Error(codes.Unavailable, "node down")}
+	client := newTestClient(t, []*fakeClient{hanging})
+
+	_, err := client.GetLatestBlockHeader(context.Background(), true)
+	require.Error(t, err)
+
+	// the endpoint hangs until the caller gives up
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	hanging.err = context.Canceled
+
+	_, err = client.GetLatestBlockHeader(ctx, true)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	statuses := client.Status()
+	assert.False(t, statuses[0].Healthy)
+	assert.Equal(t, 1, statuses[0].Failures)
+	assert.Zero(t, statuses[0].Latency)
+}
+
+func TestClient_AllEndpointsFailing(t *testing.T) {
+	down := errors.New("connection refused")
+	client := newTestClient(t, []*fakeClient{{err: down}, {err: down}})
+
+	_, err := client.GetLatestBlockHeader(context.Background(), true)
+
+	var endpointsErr EndpointsError
+	require.ErrorAs(t, err, &endpointsErr)
+	assert.Len(t, endpointsErr.Errs, 2)
+	assert.ErrorIs(t, err, down)
+}
+
+func TestClient_RoundRobin(t *testing.T) {
+	a := &fakeClient{}
+	b := &fakeClient{}
+	client := newTestClient(t, []*fakeClient{a, b})
+
+	for i := 0; i < 4; i++ {
+		_, err := client.GetLatestBlockHeader(context.Background(), true)
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, 2, a.calls)
+	assert.Equal(t, 2, b.calls)
+}
+
+func TestClient_ScriptConsistencyCheck(t *testing.T) {
+	t.Run("matching results", func(t *testing.T) {
+		a := &fakeClient{value: cadence.NewUInt64(1)}
+		b := &fakeClient{value: cadence.NewUInt64(1)}
+		client := newTestClient(t, []*fakeClient{a, b}, WithScriptConsistencyCheck())
+
+		value, err := client.ExecuteScriptAtLatestBlock(context.Background(), nil, nil)
+		require.NoError(t, err)
+		assert.Equal(t, cadence.NewUInt64(1), value)
+		assert.Equal(t, 1, a.calls)
+		assert.Equal(t, 1, b.calls)
+	})
+
+	t.Run("diverging results", func(t *testing.T) {
+		a := &fakeClient{value: cadence.NewUInt64(1)}
+		b := &fakeClient{value: cadence.NewUInt64(2)}
+		client := newTestClient(t, []*fakeClient{a, b}, WithScriptConsistencyCheck())
+
+		_, err := client.ExecuteScriptAtLatestBlock(context.Background(), nil, nil)
+
+		var inconsistent InconsistentResultError
+		require.ErrorAs(t, err, &inconsistent)
+		assert.Equal(t, [2]int{0, 1}, inconsistent.Endpoints)
+	})
+}
+
+func TestClient_CloseTwice(t *testing.T) {
+	client, err := NewClient([]access.Client{&fakeClient{}})
+	require.NoError(t, err)
+
+	require.NoError(t, client.Close())
+	assert.NotPanics(t, func() {
+		assert.NoError(t, client.Close())
+	})
+}
@@ -0,0 +1,64 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2023 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package multi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	accessHTTP "github.com/onflow/flow-go-sdk/access/http"
+)
+
+// ShouldFailover is the default failover policy.
+//
+// Errors caused by the request itself, such as invalid arguments or missing entities, are
+// returned to the caller right away since every other endpoint would reject the request in the
+// same way. Any other error, such as an unavailable node, an internal error or a timeout of the
+// node, is considered an endpoint failure and the request is retried on the next endpoint.
+func ShouldFailover(err error) bool {
+	if errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	var httpErr accessHTTP.HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.Code >= http.StatusInternalServerError || httpErr.Code == http.StatusTooManyRequests
+	}
+
+	if s, ok := status.FromError(err); ok {
+		switch s.Code() {
+		case codes.InvalidArgument,
+			codes.NotFound,
+			codes.AlreadyExists,
+			codes.PermissionDenied,
+			codes.FailedPrecondition,
+			codes.OutOfRange,
+			codes.Unimplemented,
+			codes.Unauthenticated,
+			codes.Canceled:
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,310 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2023 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package ratelimit provides client-side rate limiting for the access clients.
+//
+// A Limiter holds a token bucket for each family of Access API methods and an optional
+// limit on the number of requests in flight. The same limiter can be shared by several
+// clients, for example to respect the rate limits of a public access node across all the
+// workers of a backfill job. Both the gRPC and the HTTP clients accept a limiter through
+// their WithRateLimiter option.
+package ratelimit
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Family groups Access API methods that share a rate limit.
+type Family string
+
+const (
+	// FamilyScripts contains the script execution methods.
+	FamilyScripts Family = "scripts"
+	// FamilyEvents contains the event query methods.
+	FamilyEvents Family = "events"
+	// FamilyBlocks contains the block, collection and execution result methods.
+	FamilyBlocks Family = "blocks"
+	// FamilyTransactions contains the transaction and transaction result methods.
+	FamilyTransactions Family = "transactions"
+	// FamilyOther contains any other method, such as pings and account queries.
+	FamilyOther Family = "other"
+)
+
+// Families lists all method families.
+var Families = []Family{
+	FamilyScripts,
+	FamilyEvents,
+	FamilyBlocks,
+	FamilyTransactions,
+	FamilyOther,
+}
+
+// MethodFamily returns the family of the Access API method with the given name, e.g. "GetBlockByID".
+func MethodFamily(method string) Family {
+	switch {
+	case strings.HasPrefix(method, "ExecuteScript"):
+		return FamilyScripts
+	case strings.HasPrefix(method, "GetEvents"):
+		return FamilyEvents
+	case strings.HasPrefix(method, "GetAccount"):
+		return FamilyOther
+	case strings.Contains(method, "Transaction"):
+		return FamilyTransactions
+	case strings.Contains(method, "Block"),
+		strings.Contains(method, "Collection"),
+		strings.Contains(method, "ExecutionResult"):
+		return FamilyBlocks
+	default:
+		return FamilyOther
+	}
+}
+
+// Rate is the limit of a token bucket.
+type Rate struct {
+	// PerSecond is the number of requests allowed per second.
+	PerSecond float64
+	// Burst is the maximum number of requests allowed at once.
+	Burst int
+}
+
+// Stats contains the observed state of a method family.
+type Stats struct {
+	// Waiting is the number of requests currently waiting for a token or an in-flight slot.
+	Waiting int
+	// InFlight is the number of requests currently being executed.
+	InFlight int
+	// Requests is the total number of requests that were let through.
+	Requests uint64
+	// TotalWait is the total time requests spent waiting.
+	TotalWait time.Duration
+	// MaxWait is the longest time a single request spent waiting.
+	MaxWait time.Duration
+}
+
+// Option configures a Limiter.
+type Option func(*Limiter)
+
+// WithRate limits the requests of the given family to the provided rate.
+//
+// Families without a rate are not rate limited.
+func WithRate(family Family, rate Rate) Option {
+	return func(l *Limiter) {
+		l.buckets[family] = newBucket(rate)
+	}
+}
+
+// WithMaxInFlight limits the number of requests executed concurrently over all families.
+func WithMaxInFlight(max int) Option {
+	return func(l *Limiter) {
+		if max > 0 {
+			l.slots = make(chan struct{}, max)
+		}
+	}
+}
+
+// WithObserver sets a function called with the time spent waiting by every request let through.
+func WithObserver(observer func(family Family, wait time.Duration)) Option {
+	return func(l *Limiter) {
+		l.observer = observer
+	}
+}
+
+// A Limiter limits the rate and concurrency of Access API requests.
+type Limiter struct {
+	buckets  map[Family]*bucket
+	slots    chan struct{}
+	observer func(family Family, wait time.Duration)
+
+	mu    sync.Mutex
+	stats map[Family]*Stats
+}
+
+// NewLimiter creates a new limiter, without any option no request is limited.
+func NewLimiter(opts ...Option) *Limiter {
+	l := &Limiter{
+		buckets: make(map[Family]*bucket),
+		stats:   make(map[Family]*Stats),
+	}
+
+	for _, opt := range opts {
+		opt(l)
+	}
+
+	for _, family := range Families {
+		l.stats[family] = &Stats{}
+	}
+
+	return l
+}
+
+// Wait blocks until a request of the given family is allowed to proceed.
+//
+// The returned function must be called once the request is done to release
+// its in-flight slot. An error is returned if the context is done before the
+// request is allowed to proceed.
+func (l *Limiter) Wait(ctx context.Context, family Family) (func(), error) {
+	start := time.Now()
+	l.update(family, func(s *Stats) { s.Waiting++ })
+
+	err := l.wait(ctx, family)
+	if err != nil {
+		l.update(family, func(s *Stats) { s.Waiting-- })
+		return nil, err
+	}
+
+	wait := time.Since(start)
+	l.update(family, func(s *Stats) {
+		s.Waiting--
+		s.InFlight++
+		s.Requests++
+		s.TotalWait += wait
+		if wait > s.MaxWait {
+			s.MaxWait = wait
+		}
+	})
+
+	if l.observer != nil {
+		l.observer(family, wait)
+	}
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			if l.slots != nil {
+				<-l.slots
+			}
+			l.update(family, func(s *Stats) { s.InFlight-- })
+		})
+	}, nil
+}
+
+func (l *Limiter) wait(ctx context.Context, family Family) error {
+	b, limited := l.buckets[family]
+	if limited {
+		delay := b.reserve(time.Now())
+		if delay > 0 {
+			timer := time.NewTimer(delay)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				b.cancel()
+				return ctx.Err()
+			case <-timer.C:
+			}
+		}
+	}
+
+	if l.slots != nil {
+		select {
+		case <-ctx.Done():
+			// the request is not sent, so the token it reserved is given back
+			if limited {
+				b.cancel()
+			}
+			return ctx.Err()
+		case l.slots <- struct{}{}:
+		}
+	}
+
+	return nil
+}
+
+func (l *Limiter) update(family Family, f func(s *Stats)) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	s, ok := l.stats[family]
+	if !ok {
+		s = &Stats{}
+		l.stats[family] = s
+	}
+	f(s)
+}
+
+// Stats returns the observed state of every method family.
+func (l *Limiter) Stats() map[Family]Stats {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	stats := make(map[Family]Stats, len(l.stats))
+	for family, s := range l.stats {
+		stats[family] = *s
+	}
+
+	return stats
+}
+
+// bucket is a token bucket refilled continuously at a fixed rate.
+//
+// Tokens are reserved ahead of time, so the token count becomes negative
+// while requests are queued, which keeps the queue first-in first-out.
+type bucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newBucket(rate Rate) *bucket {
+	burst := float64(rate.Burst)
+	if burst < 1 {
+		burst = 1
+	}
+
+	return &bucket{
+		rate:   rate.PerSecond,
+		burst:  burst,
+		tokens: burst,
+		last:   time.Now(),
+	}
+}
+
+// reserve takes a token and returns how long to wait before it can be used.
+func (b *bucket) reserve(now time.Time) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.rate <= 0 {
+		return 0
+	}
+
+	b.tokens += now.Sub(b.last).Seconds() * b.rate
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+	b.last = now
+
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+// cancel gives back a token reserved by a request which gave up waiting.
+func (b *bucket) cancel() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.tokens++
+}
@@ -0,0 +1,121 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2023 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMethodFamily(t *testing.T) {
+	tests := map[string]Family{
+		"ExecuteScriptAtBlockHeight":     FamilyScripts,
+		"GetEventsForHeightRange":        FamilyEvents,
+		"GetBlockHeaderByID":             FamilyBlocks,
+		"GetCollectionByID":              FamilyBlocks,
+		"GetExecutionResultForBlockID":   FamilyBlocks,
+		"SendTransaction":                FamilyTransactions,
+		"GetTransactionResultsByBlockID": FamilyTransactions,
+		"GetAccountAtBlockHeight":        FamilyOther,
+		"Ping":                           FamilyOther,
+	}
+
+	for method, family := range tests {
+		assert.Equal(t, family, MethodFamily(method), method)
+	}
+}
+
+func TestLimiter_Rate(t *testing.T) {
+	limiter := NewLimiter(WithRate(FamilyScripts, Rate{PerSecond: 100, Burst: 1}))
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		release, err := limiter.Wait(context.Background(), FamilyScripts)
+		require.NoError(t, err)
+		release()
+	}
+
+	// the burst allows the first request right away, the next two wait 10ms each
+	assert.GreaterOrEqual(t, time.Since(start), 20*time.Millisecond)
+
+	stats := limiter.Stats()[FamilyScripts]
+	assert.Equal(t, uint64(3), stats.Requests)
+	assert.Equal(t, 0, stats.InFlight)
+	assert.Greater(t, stats.TotalWait, time.Duration(0))
+
+	// other families are not limited
+	release, err := limiter.Wait(context.Background(), FamilyBlocks)
+	require.NoError(t, err)
+	release()
+	assert.Equal(t, uint64(1), limiter.Stats()[FamilyBlocks].Requests)
+}
+
+func TestLimiter_MaxInFlight(t *testing.T) {
+	limiter := NewLimiter(WithMaxInFlight(1))
+
+	release, err := limiter.Wait(context.Background(), FamilyBlocks)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err = limiter.Wait(ctx, FamilyEvents)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	stats := limiter.Stats()
+	assert.Equal(t, 1, stats[FamilyBlocks].InFlight)
+	assert.Equal(t, 0, stats[FamilyEvents].Waiting)
+
+	release()
+	release() // releasing twice is a no-op
+
+	release, err = limiter.Wait(context.Background(), FamilyEvents)
+	require.NoError(t, err)
+	release()
+	assert.Equal(t, 0, limiter.Stats()[FamilyBlocks].InFlight)
+}
+
+func TestLimiter_CancelledWhileInFlightKeepsToken(t *testing.T) {
+	// the bucket practically never refills, so only given back tokens can be reused
+	limiter := NewLimiter(
+		WithRate(FamilyScripts, Rate{PerSecond: 0.001, Burst: 2}),
+		WithMaxInFlight(1),
+	)
+
+	release, err := limiter.Wait(context.Background(), FamilyScripts)
+	require.NoError(t, err)
+
+	// the second request gets a token but gives up waiting for an in-flight slot
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = limiter.Wait(ctx, FamilyScripts)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	release()
+
+	ctx, cancel = context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	release, err = limiter.Wait(ctx, FamilyScripts)
+	require.NoError(t, err)
+	release()
+}
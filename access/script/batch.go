@@ -0,0 +1,287 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2023 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package script
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/parser"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/access"
+)
+
+// DefaultBatchConcurrency is the default number of scripts executed concurrently by a batch.
+const DefaultBatchConcurrency = 8
+
+// batchItemFunction is the name the main function is renamed to in merged scripts.
+const batchItemFunction = "__batchItem"
+
+// A BatchResult is the result of one argument set of a batch.
+type BatchResult struct {
+	Value cadence.Value
+	Err   error
+}
+
+// A Batch executes the same script for many argument sets at a pinned block height,
+// so that all the results are computed from the same execution state.
+type Batch struct {
+	code        []byte
+	height      uint64
+	concurrency int
+	mergeSize   int
+}
+
+// BatchOption configures a batch.
+type BatchOption func(*Batch)
+
+// WithConcurrency sets the maximum number of scripts executed concurrently.
+func WithConcurrency(concurrency int) BatchOption {
+	return func(b *Batch) {
+		if concurrency > 0 {
+			b.concurrency = concurrency
+		}
+	}
+}
+
+// WithMerging executes up to size argument sets in a single script, by generating a wrapper
+// script which calls the main function for each argument set and returns the results in an array.
+//
+// Merging is only used if the script has a main function with a return type, and a merged script
+// which fails is retried one argument set at a time, so that errors are reported per argument set.
+func WithMerging(size int) BatchOption {
+	return func(b *Batch) {
+		b.mergeSize = size
+	}
+}
+
+// NewBatch returns a batch executing the script at the given block height.
+func NewBatch(code []byte, height uint64, opts ...BatchOption) *Batch {
+	b := &Batch{
+		code:        code,
+		height:      height,
+		concurrency: DefaultBatchConcurrency,
+	}
+	for _, opt := range opts {
+		opt(b)
+	}
+	return b
+}
+
+// batchJob is a script execution covering one or more argument sets.
+type batchJob struct {
+	indices   []int
+	arguments [][]cadence.Value
+}
+
+// Execute executes the script for each argument set, and returns the results in the same order.
+//
+// Argument sets are converted as done by Script.Arguments.
+func (b *Batch) Execute(ctx context.Context, client access.Client, argumentSets [][]any) []BatchResult {
+	results := make([]BatchResult, len(argumentSets))
+
+	parameters, known := scriptParameters(b.code)
+
+	var pending []int
+	converted := make([][]cadence.Value, len(argumentSets))
+	for i, args := range argumentSets {
+		values, err := convertArguments(parameters, known, args)
+		if err != nil {
+			results[i].Err = err
+			continue
+		}
+		converted[i] = values
+		pending = append(pending, i)
+	}
+
+	var jobs []batchJob
+	mergeSize := 1
+	if b.mergeSize > 1 && canMerge(b.code) {
+		mergeSize = b.mergeSize
+	}
+	for start := 0; start < len(pending); start += mergeSize {
+		end := start + mergeSize
+		if end > len(pending) {
+			end = len(pending)
+		}
+
+		job := batchJob{indices: pending[start:end]}
+		for _, i := range job.indices {
+			job.arguments = append(job.arguments, converted[i])
+		}
+		jobs = append(jobs, job)
+	}
+
+	sem := make(chan struct{}, b.concurrency)
+	var wg sync.WaitGroup
+
+	for _, job := range jobs {
+		if ctx.Err() == nil {
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+			}
+		}
+		// the semaphore is only acquired if the context is not done
+		if ctx.Err() != nil {
+			for _, i := range job.indices {
+				results[i].Err = ctx.Err()
+			}
+			continue
+		}
+
+		wg.Add(1)
+		go func(job batchJob) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			b.executeJob(ctx, client, job, results)
+		}(job)
+	}
+
+	wg.Wait()
+	return results
+}
+
+func (b *Batch) executeJob(ctx context.Context, client access.Client, job batchJob, results []BatchResult) {
+	if len(job.indices) > 1 {
+		values, err := b.executeMerged(ctx, client, job.arguments)
+		if err == nil {
+			for j, i := range job.indices {
+				results[i].Value = values[j]
+			}
+			return
+		}
+		// fall back to executing the argument sets one at a time, to find which ones fail
+	}
+
+	for j, i := range job.indices {
+		value, err := client.ExecuteScriptAtBlockHeight(ctx, b.height, b.code, job.arguments[j])
+		results[i] = BatchResult{Value: value, Err: err}
+	}
+}
+
+func (b *Batch) executeMerged(ctx context.Context, client access.Client, argumentSets [][]cadence.Value) ([]cadence.Value, error) {
+	code, err := mergeScript(b.code, len(argumentSets))
+	if err != nil {
+		return nil, err
+	}
+
+	var arguments []cadence.Value
+	for _, args := range argumentSets {
+		arguments = append(arguments, args...)
+	}
+
+	value, err := client.ExecuteScriptAtBlockHeight(ctx, b.height, code, arguments)
+	if err != nil {
+		return nil, err
+	}
+
+	array, ok := value.(cadence.Array)
+	if !ok || len(array.Values) != len(argumentSets) {
+		return nil, fmt.Errorf("unexpected merged script result %s", value)
+	}
+
+	return array.Values, nil
+}
+
+// mainFunction returns the main function of a script.
+func mainFunction(code []byte) (*ast.FunctionDeclaration, error) {
+	program, err := parser.ParseProgram(nil, code, parser.Config{})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, function := range program.FunctionDeclarations() {
+		if function.Identifier.Identifier == "main" {
+			return function, nil
+		}
+	}
+
+	return nil, flow.ErrNoEntryPoint
+}
+
+// canMerge returns true if the script has a main function returning a value.
+func canMerge(code []byte) bool {
+	main, err := mainFunction(code)
+	if err != nil || main.ReturnTypeAnnotation == nil || main.ReturnTypeAnnotation.IsResource {
+		return false
+	}
+
+	returnType := main.ReturnTypeAnnotation.Type
+	if nominal, ok := returnType.(*ast.NominalType); ok && nominal.Identifier.Identifier == "Void" {
+		return false
+	}
+
+	return returnType != nil && returnType.String() != ""
+}
+
+// mergeScript returns a script calling the main function of the given script for count
+// argument sets, and returning the results in an array.
+//
+// The main function is renamed and a new main function taking the parameters of all the
+// argument sets is added.
+func mergeScript(code []byte, count int) ([]byte, error) {
+	main, err := mainFunction(code)
+	if err != nil {
+		return nil, err
+	}
+
+	offset := main.Identifier.Pos.Offset
+	if offset+len("main") > len(code) || string(code[offset:offset+len("main")]) != "main" {
+		return nil, fmt.Errorf("failed to locate the main function")
+	}
+
+	var b strings.Builder
+	b.Write(code[:offset])
+	b.WriteString(batchItemFunction)
+	b.Write(code[offset+len("main"):])
+
+	var parameters, calls []string
+	for i := 0; i < count; i++ {
+		var arguments []string
+		for j, p := range main.ParameterList.Parameters {
+			name := fmt.Sprintf("arg%d_%d", i, j)
+			parameters = append(parameters, fmt.Sprintf("%s: %s", name, p.TypeAnnotation.Type))
+
+			if label := p.EffectiveArgumentLabel(); label != "_" {
+				arguments = append(arguments, fmt.Sprintf("%s: %s", label, name))
+			} else {
+				arguments = append(arguments, name)
+			}
+		}
+		calls = append(calls, fmt.Sprintf("%s(%s)", batchItemFunction, strings.Join(arguments, ", ")))
+	}
+
+	_, _ = fmt.Fprintf(
+		&b,
+		"\n\npub fun main(%s): [%s] {\n\treturn [\n\t\t%s\n\t]\n}\n",
+		strings.Join(parameters, ", "),
+		main.ReturnTypeAnnotation.Type,
+		strings.Join(calls, ",\n\t\t"),
+	)
+
+	return []byte(b.String()), nil
+}
@@ -0,0 +1,178 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2023 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package script
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/onflow/cadence"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/access"
+)
+
+// echoClient returns the first argument of each argument set, and fails for the failing address.
+type echoClient struct {
+	access.Client
+	failing cadence.Value
+
+	mu      sync.Mutex
+	heights []uint64
+	merged  int
+}
+
+func (c *echoClient) ExecuteScriptAtBlockHeight(_ context.Context, height uint64, code []byte, arguments []cadence.Value) (cadence.Value, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.heights = append(c.heights, height)
+
+	for _, arg := range arguments {
+		if arg == c.failing {
+			return nil, errors.New("script failed")
+		}
+	}
+
+	if !strings.Contains(string(code), batchItemFunction) {
+		return arguments[0], nil
+	}
+
+	c.merged++
+	if _, err := flow.ParseScriptParameters(code); err != nil {
+		return nil, err
+	}
+
+	// the test script has two parameters per argument set
+	values := make([]cadence.Value, 0, len(arguments)/2)
+	for i := 0; i < len(arguments); i += 2 {
+		values = append(values, arguments[i])
+	}
+	return cadence.NewArray(values), nil
+}
+
+const batchScript = `
+pub fun main(account: Address, _ minimum: UFix64): Address {
+	return account
+}
+`
+
+func TestBatch_Execute(t *testing.T) {
+	ctx := context.Background()
+
+	gen, err := flow.NewChainAddressGenerator(flow.Emulator)
+	require.NoError(t, err)
+	var argumentSets [][]any
+	var addresses []flow.Address
+	for i := 0; i < 7; i++ {
+		address := gen.NextAddress()
+		addresses = append(addresses, address)
+		argumentSets = append(argumentSets, []any{address, "1.0"})
+	}
+	// an argument set which cannot be converted
+	argumentSets = append(argumentSets, []any{addresses[0], 1.0})
+
+	t.Run("individual", func(t *testing.T) {
+		client := &echoClient{failing: cadence.NewAddress(addresses[3])}
+
+		results := NewBatch([]byte(batchScript), 42, WithConcurrency(2)).Execute(ctx, client, argumentSets)
+		require.Len(t, results, 8)
+
+		for i, address := range addresses {
+			if i == 3 {
+				assert.Error(t, results[i].Err)
+				continue
+			}
+			require.NoError(t, results[i].Err)
+			assert.Equal(t, cadence.NewAddress(address), results[i].Value)
+		}
+
+		var argErr ArgumentError
+		require.ErrorAs(t, results[7].Err, &argErr)
+		assert.Equal(t, "minimum", argErr.Parameter)
+
+		assert.Len(t, client.heights, 7)
+		for _, height := range client.heights {
+			assert.Equal(t, uint64(42), height)
+		}
+		assert.Zero(t, client.merged)
+	})
+
+	t.Run("merged", func(t *testing.T) {
+		client := &echoClient{failing: cadence.NewAddress(addresses[3])}
+
+		results := NewBatch([]byte(batchScript), 42, WithMerging(3)).Execute(ctx, client, argumentSets)
+		require.Len(t, results, 8)
+
+		for i, address := range addresses {
+			if i == 3 {
+				assert.Error(t, results[i].Err)
+				continue
+			}
+			require.NoError(t, results[i].Err)
+			assert.Equal(t, cadence.NewAddress(address), results[i].Value)
+		}
+		assert.Error(t, results[7].Err)
+
+		// the merged script of the second chunk fails and is retried one argument set at a time,
+		// and the last chunk has a single argument set which is executed as is
+		assert.Equal(t, 1, client.merged)
+		assert.Len(t, client.heights, 6)
+	})
+
+	t.Run("argument count", func(t *testing.T) {
+		client := &echoClient{}
+
+		results := NewBatch([]byte(batchScript), 42).Execute(ctx, client, [][]any{{addresses[0]}})
+		require.Len(t, results, 1)
+		assert.Equal(t, flow.ArgumentCountError{Expected: 2, Actual: 1}, results[0].Err)
+		assert.Empty(t, client.heights)
+	})
+
+	t.Run("cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(ctx)
+		cancel()
+
+		results := NewBatch([]byte(batchScript), 42, WithConcurrency(1)).Execute(ctx, &echoClient{}, argumentSets[:3])
+		for _, result := range results {
+			assert.Error(t, result.Err)
+		}
+	})
+}
+
+func TestMergeScript(t *testing.T) {
+	code, err := mergeScript([]byte(batchScript), 2)
+	require.NoError(t, err)
+
+	parameters, err := flow.ParseScriptParameters(code)
+	require.NoError(t, err)
+	require.Len(t, parameters, 4)
+	assert.Equal(t, "UFix64", parameters[3].Type)
+
+	assert.Contains(t, string(code), "__batchItem(account: arg0_0, arg0_1)")
+	assert.Contains(t, string(code), "): [Address] {")
+
+	assert.False(t, canMerge([]byte(`pub fun main() {}`)))
+	assert.False(t, canMerge([]byte(`transaction {}`)))
+	assert.True(t, canMerge([]byte(batchScript)))
+}
@@ -0,0 +1,627 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2023 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package script
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/parser"
+
+	"github.com/onflow/flow-go-sdk"
+)
+
+var (
+	cadenceValueType = reflect.TypeOf((*cadence.Value)(nil)).Elem()
+	addressType      = reflect.TypeOf(flow.Address{})
+	bigIntType       = reflect.TypeOf(big.Int{})
+)
+
+// ToCadence converts a Go value to a Cadence value, inferring the Cadence type from the Go type.
+//
+// The supported values are cadence.Value values, strings, booleans, integers, *big.Int values,
+// flow.Address values, slices and arrays, maps, and pointers to these, where a nil pointer is
+// converted to nil. Fixed-point numbers have no Go equivalent, and must be passed as cadence.Value
+// values or as decimal strings using ToCadenceType.
+func ToCadence(v any) (cadence.Value, error) {
+	if v == nil {
+		return cadence.NewOptional(nil), nil
+	}
+	if value, ok := v.(cadence.Value); ok {
+		return value, nil
+	}
+
+	switch v := v.(type) {
+	case flow.Address:
+		return cadence.NewAddress(v), nil
+	case *big.Int:
+		return cadence.NewIntFromBig(v), nil
+	case string:
+		return cadence.NewString(v)
+	case bool:
+		return cadence.NewBool(v), nil
+	case int:
+		return cadence.NewInt(v), nil
+	case int8:
+		return cadence.NewInt8(v), nil
+	case int16:
+		return cadence.NewInt16(v), nil
+	case int32:
+		return cadence.NewInt32(v), nil
+	case int64:
+		return cadence.NewInt64(v), nil
+	case uint:
+		return cadence.NewUInt(v), nil
+	case uint8:
+		return cadence.NewUInt8(v), nil
+	case uint16:
+		return cadence.NewUInt16(v), nil
+	case uint32:
+		return cadence.NewUInt32(v), nil
+	case uint64:
+		return cadence.NewUInt64(v), nil
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Pointer:
+		if rv.IsNil() {
+			return cadence.NewOptional(nil), nil
+		}
+		inner, err := ToCadence(rv.Elem().Interface())
+		if err != nil {
+			return nil, err
+		}
+		return cadence.NewOptional(inner), nil
+
+	case reflect.Slice, reflect.Array:
+		return convertArray(rv, func(v any) (cadence.Value, error) { return ToCadence(v) })
+
+	case reflect.Map:
+		convert := func(v any) (cadence.Value, error) { return ToCadence(v) }
+		return convertDictionary(rv, convert, convert)
+	}
+
+	return nil, fmt.Errorf("unsupported Go type %T", v)
+}
+
+// ToCadenceType converts a Go value to a Cadence value of the given type, e.g. "UFix64" or "[Address]".
+//
+// In addition to the values supported by ToCadence, numbers can be passed as decimal strings,
+// which is the only way to pass fixed-point numbers, addresses can be passed as hex strings and
+// integers are converted to the declared integer type. Types which are not built-in, like
+// composites, fall back to ToCadence.
+func ToCadenceType(v any, typ string) (cadence.Value, error) {
+	t, errs := parser.ParseType(nil, []byte(typ), parser.Config{})
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("invalid type %s: %w", typ, errs[0])
+	}
+
+	return toCadenceType(v, t)
+}
+
+func toCadenceType(v any, t ast.Type) (cadence.Value, error) {
+	if value, ok := v.(cadence.Value); ok {
+		return value, nil
+	}
+
+	switch t := t.(type) {
+	case *ast.NominalType:
+		if len(t.NestedIdentifiers) > 0 {
+			return ToCadence(v)
+		}
+		return toNominalType(v, t.Identifier.Identifier)
+
+	case *ast.OptionalType:
+		if v == nil {
+			return cadence.NewOptional(nil), nil
+		}
+		rv := reflect.ValueOf(v)
+		if rv.Kind() == reflect.Pointer {
+			if rv.IsNil() {
+				return cadence.NewOptional(nil), nil
+			}
+			v = rv.Elem().Interface()
+		}
+		inner, err := toCadenceType(v, t.Type)
+		if err != nil {
+			return nil, err
+		}
+		return cadence.NewOptional(inner), nil
+
+	case *ast.VariableSizedType:
+		rv := reflect.ValueOf(v)
+		if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+			return nil, fmt.Errorf("cannot convert %T to %s", v, t)
+		}
+		return convertArray(rv, func(v any) (cadence.Value, error) { return toCadenceType(v, t.Type) })
+
+	case *ast.ConstantSizedType:
+		rv := reflect.ValueOf(v)
+		if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+			return nil, fmt.Errorf("cannot convert %T to %s", v, t)
+		}
+		if t.Size != nil && t.Size.Value != nil && big.NewInt(int64(rv.Len())).Cmp(t.Size.Value) != 0 {
+			return nil, fmt.Errorf("cannot convert %d elements to %s", rv.Len(), t)
+		}
+		return convertArray(rv, func(v any) (cadence.Value, error) { return toCadenceType(v, t.Type) })
+
+	case *ast.DictionaryType:
+		rv := reflect.ValueOf(v)
+		if rv.Kind() != reflect.Map {
+			return nil, fmt.Errorf("cannot convert %T to %s", v, t)
+		}
+		return convertDictionary(
+			rv,
+			func(k any) (cadence.Value, error) { return toCadenceType(k, t.KeyType) },
+			func(v any) (cadence.Value, error) { return toCadenceType(v, t.ValueType) },
+		)
+	}
+
+	return ToCadence(v)
+}
+
+func toNominalType(v any, name string) (cadence.Value, error) {
+	switch name {
+	case "String":
+		s, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("cannot convert %T to String", v)
+		}
+		return cadence.NewString(s)
+
+	case "Character":
+		s, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("cannot convert %T to Character", v)
+		}
+		return cadence.NewCharacter(s)
+
+	case "Bool":
+		b, ok := v.(bool)
+		if !ok {
+			return nil, fmt.Errorf("cannot convert %T to Bool", v)
+		}
+		return cadence.NewBool(b), nil
+
+	case "Address":
+		switch a := v.(type) {
+		case flow.Address:
+			return cadence.NewAddress(a), nil
+		case string:
+			address, err := flow.ParseAddressLenient(a)
+			if err != nil {
+				return nil, err
+			}
+			return cadence.NewAddress(address), nil
+		}
+		return nil, fmt.Errorf("cannot convert %T to Address", v)
+
+	case "UFix64", "Fix64":
+		s, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("cannot convert %T to %s, fixed-point numbers must be passed as decimal strings", v, name)
+		}
+		if name == "UFix64" {
+			return cadence.NewUFix64(s)
+		}
+		return cadence.NewFix64(s)
+	}
+
+	if isIntegerType(name) {
+		i, err := toBigInt(v)
+		if err != nil {
+			return nil, fmt.Errorf("cannot convert %T to %s: %w", v, name, err)
+		}
+		return newInteger(i, name)
+	}
+
+	return ToCadence(v)
+}
+
+func convertArray(rv reflect.Value, convert func(any) (cadence.Value, error)) (cadence.Value, error) {
+	values := make([]cadence.Value, rv.Len())
+	for i := range values {
+		value, err := convert(rv.Index(i).Interface())
+		if err != nil {
+			return nil, fmt.Errorf("element %d: %w", i, err)
+		}
+		values[i] = value
+	}
+	return cadence.NewArray(values), nil
+}
+
+func convertDictionary(
+	rv reflect.Value,
+	convertKey func(any) (cadence.Value, error),
+	convertValue func(any) (cadence.Value, error),
+) (cadence.Value, error) {
+	pairs := make([]cadence.KeyValuePair, 0, rv.Len())
+
+	iter := rv.MapRange()
+	for iter.Next() {
+		key, err := convertKey(iter.Key().Interface())
+		if err != nil {
+			return nil, fmt.Errorf("key %v: %w", iter.Key(), err)
+		}
+		value, err := convertValue(iter.Value().Interface())
+		if err != nil {
+			return nil, fmt.Errorf("value of key %v: %w", iter.Key(), err)
+		}
+		pairs = append(pairs, cadence.KeyValuePair{Key: key, Value: value})
+	}
+
+	// map iteration order is random, sort the pairs so that the arguments are deterministic
+	sort.Slice(pairs, func(i, j int) bool {
+		return pairs[i].Key.String() < pairs[j].Key.String()
+	})
+
+	return cadence.NewDictionary(pairs), nil
+}
+
+// fixedSizeIntegers are the signed and unsigned integer types with at most 64 bits.
+var fixedSizeIntegers = map[string]struct {
+	signed bool
+	bits   uint
+}{
+	"Int8": {true, 8}, "Int16": {true, 16}, "Int32": {true, 32}, "Int64": {true, 64},
+	"UInt8": {false, 8}, "UInt16": {false, 16}, "UInt32": {false, 32}, "UInt64": {false, 64},
+	"Word8": {false, 8}, "Word16": {false, 16}, "Word32": {false, 32}, "Word64": {false, 64},
+}
+
+func isIntegerType(name string) bool {
+	switch name {
+	case "Int", "UInt", "Int128", "Int256", "UInt128", "UInt256", "Word128", "Word256":
+		return true
+	}
+	_, ok := fixedSizeIntegers[name]
+	return ok
+}
+
+func toBigInt(v any) (*big.Int, error) {
+	switch v := v.(type) {
+	case *big.Int:
+		return v, nil
+	case string:
+		i, ok := new(big.Int).SetString(v, 10)
+		if !ok {
+			return nil, fmt.Errorf("invalid integer %q", v)
+		}
+		return i, nil
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return big.NewInt(rv.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return new(big.Int).SetUint64(rv.Uint()), nil
+	}
+
+	return nil, fmt.Errorf("not an integer")
+}
+
+// newInteger returns the Cadence integer of the given type, or an error if the value is out of range.
+func newInteger(i *big.Int, name string) (cadence.Value, error) {
+	switch name {
+	case "Int":
+		return cadence.NewIntFromBig(i), nil
+	case "UInt":
+		return cadence.NewUIntFromBig(i)
+	case "Int128":
+		return cadence.NewInt128FromBig(i)
+	case "Int256":
+		return cadence.NewInt256FromBig(i)
+	case "UInt128":
+		return cadence.NewUInt128FromBig(i)
+	case "UInt256":
+		return cadence.NewUInt256FromBig(i)
+	case "Word128":
+		return cadence.NewWord128FromBig(i)
+	case "Word256":
+		return cadence.NewWord256FromBig(i)
+	}
+
+	size, ok := fixedSizeIntegers[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown integer type %s", name)
+	}
+
+	if size.signed {
+		min := new(big.Int).Neg(new(big.Int).Lsh(big.NewInt(1), size.bits-1))
+		max := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), size.bits-1), big.NewInt(1))
+		if i.Cmp(min) < 0 || i.Cmp(max) > 0 {
+			return nil, fmt.Errorf("%s is out of range for %s", i, name)
+		}
+	} else if i.Sign() < 0 || i.BitLen() > int(size.bits) {
+		return nil, fmt.Errorf("%s is out of range for %s", i, name)
+	}
+
+	switch name {
+	case "Int8":
+		return cadence.NewInt8(int8(i.Int64())), nil
+	case "Int16":
+		return cadence.NewInt16(int16(i.Int64())), nil
+	case "Int32":
+		return cadence.NewInt32(int32(i.Int64())), nil
+	case "Int64":
+		return cadence.NewInt64(i.Int64()), nil
+	case "UInt8":
+		return cadence.NewUInt8(uint8(i.Uint64())), nil
+	case "UInt16":
+		return cadence.NewUInt16(uint16(i.Uint64())), nil
+	case "UInt32":
+		return cadence.NewUInt32(uint32(i.Uint64())), nil
+	case "UInt64":
+		return cadence.NewUInt64(i.Uint64()), nil
+	case "Word8":
+		return cadence.NewWord8(uint8(i.Uint64())), nil
+	case "Word16":
+		return cadence.NewWord16(uint16(i.Uint64())), nil
+	case "Word32":
+		return cadence.NewWord32(uint32(i.Uint64())), nil
+	case "Word64":
+		return cadence.NewWord64(i.Uint64()), nil
+	}
+
+	return nil, fmt.Errorf("unknown integer type %s", name)
+}
+
+// Decode decodes a Cadence value into dest, which must be a non-nil pointer.
+//
+// The supported destinations are:
+//   - cadence.Value, and any which receives the value returned by ToGoValue
+//   - strings for strings, characters, addresses, paths and numbers, using their Cadence representation,
+//     which is the only Go representation of fixed-point numbers
+//   - booleans, Go integers and big.Int values for integers, and flow.Address values for addresses
+//   - slices and arrays for Cadence arrays, and maps for dictionaries
+//   - structs for composites, where fields are matched by their `cadence` tag or case-insensitively by name
+//   - pointers to these, set to nil for nil optionals
+func Decode(value cadence.Value, dest any) error {
+	rv := reflect.ValueOf(dest)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return fmt.Errorf("destination must be a non-nil pointer, got %T", dest)
+	}
+
+	return decode(value, rv.Elem())
+}
+
+func decode(value cadence.Value, rv reflect.Value) error {
+	if rv.Type() == cadenceValueType {
+		rv.Set(reflect.ValueOf(value))
+		return nil
+	}
+
+	if optional, ok := value.(cadence.Optional); ok {
+		if optional.Value == nil {
+			rv.Set(reflect.Zero(rv.Type()))
+			return nil
+		}
+		value = optional.Value
+	}
+
+	if rv.Kind() == reflect.Pointer {
+		elem := reflect.New(rv.Type().Elem())
+		if err := decode(value, elem.Elem()); err != nil {
+			return err
+		}
+		rv.Set(elem)
+		return nil
+	}
+
+	if rv.Kind() == reflect.Interface && rv.NumMethod() == 0 {
+		if goValue := value.ToGoValue(); goValue != nil {
+			rv.Set(reflect.ValueOf(goValue))
+		}
+		return nil
+	}
+
+	mismatch := func() error {
+		return fmt.Errorf("cannot decode %s into %s", value, rv.Type())
+	}
+
+	switch v := value.(type) {
+	case cadence.Bool:
+		if rv.Kind() != reflect.Bool {
+			return mismatch()
+		}
+		rv.SetBool(bool(v))
+		return nil
+
+	case cadence.Address:
+		if rv.Type() == addressType {
+			rv.Set(reflect.ValueOf(flow.BytesToAddress(v.Bytes())))
+			return nil
+		}
+
+	case cadence.Array:
+		return decodeArray(v, rv)
+
+	case cadence.Dictionary:
+		return decodeDictionary(v, rv)
+
+	case composite:
+		return decodeComposite(v, rv)
+	}
+
+	if rv.Kind() == reflect.String {
+		if s, ok := value.(cadence.String); ok {
+			rv.SetString(string(s))
+		} else if c, ok := value.(cadence.Character); ok {
+			rv.SetString(string(c))
+		} else {
+			rv.SetString(value.String())
+		}
+		return nil
+	}
+
+	if value.Type() != nil && isIntegerType(value.Type().ID()) {
+		i, ok := new(big.Int).SetString(value.String(), 10)
+		if !ok {
+			return mismatch()
+		}
+		return decodeInteger(i, rv, mismatch)
+	}
+
+	return mismatch()
+}
+
+func decodeInteger(i *big.Int, rv reflect.Value, mismatch func() error) error {
+	if rv.Type() == bigIntType {
+		rv.Set(reflect.ValueOf(*new(big.Int).Set(i)))
+		return nil
+	}
+
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if !i.IsInt64() || rv.OverflowInt(i.Int64()) {
+			return fmt.Errorf("%s overflows %s", i, rv.Type())
+		}
+		rv.SetInt(i.Int64())
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if !i.IsUint64() || rv.OverflowUint(i.Uint64()) {
+			return fmt.Errorf("%s overflows %s", i, rv.Type())
+		}
+		rv.SetUint(i.Uint64())
+		return nil
+	}
+
+	return mismatch()
+}
+
+func decodeArray(array cadence.Array, rv reflect.Value) error {
+	switch rv.Kind() {
+	case reflect.Slice:
+		slice := reflect.MakeSlice(rv.Type(), len(array.Values), len(array.Values))
+		for i, v := range array.Values {
+			if err := decode(v, slice.Index(i)); err != nil {
+				return fmt.Errorf("element %d: %w", i, err)
+			}
+		}
+		rv.Set(slice)
+		return nil
+
+	case reflect.Array:
+		if rv.Len() != len(array.Values) {
+			return fmt.Errorf("cannot decode %d elements into %s", len(array.Values), rv.Type())
+		}
+		for i, v := range array.Values {
+			if err := decode(v, rv.Index(i)); err != nil {
+				return fmt.Errorf("element %d: %w", i, err)
+			}
+		}
+		return nil
+	}
+
+	return fmt.Errorf("cannot decode array into %s", rv.Type())
+}
+
+func decodeDictionary(dictionary cadence.Dictionary, rv reflect.Value) error {
+	if rv.Kind() != reflect.Map {
+		return fmt.Errorf("cannot decode dictionary into %s", rv.Type())
+	}
+
+	m := reflect.MakeMapWithSize(rv.Type(), len(dictionary.Pairs))
+	for _, pair := range dictionary.Pairs {
+		key := reflect.New(rv.Type().Key()).Elem()
+		if err := decode(pair.Key, key); err != nil {
+			return fmt.Errorf("key %s: %w", pair.Key, err)
+		}
+		value := reflect.New(rv.Type().Elem()).Elem()
+		if err := decode(pair.Value, value); err != nil {
+			return fmt.Errorf("value of key %s: %w", pair.Key, err)
+		}
+		m.SetMapIndex(key, value)
+	}
+	rv.Set(m)
+	return nil
+}
+
+// composite is implemented by the Cadence composite values: structs, resources, events, contracts and enums.
+type composite interface {
+	cadence.Value
+	GetFields() []cadence.Field
+	GetFieldValues() []cadence.Value
+}
+
+func decodeComposite(c composite, rv reflect.Value) error {
+	fields := c.GetFields()
+	values := c.GetFieldValues()
+	if len(fields) != len(values) {
+		return fmt.Errorf("cannot decode composite %s without type information", c)
+	}
+
+	switch rv.Kind() {
+	case reflect.Map:
+		if rv.Type().Key().Kind() != reflect.String {
+			return fmt.Errorf("cannot decode composite into %s", rv.Type())
+		}
+		m := reflect.MakeMapWithSize(rv.Type(), len(fields))
+		for i, field := range fields {
+			value := reflect.New(rv.Type().Elem()).Elem()
+			if err := decode(values[i], value); err != nil {
+				return fmt.Errorf("field %s: %w", field.Identifier, err)
+			}
+			m.SetMapIndex(reflect.ValueOf(field.Identifier).Convert(rv.Type().Key()), value)
+		}
+		rv.Set(m)
+		return nil
+
+	case reflect.Struct:
+		for i, field := range fields {
+			target, ok := structField(rv, field.Identifier)
+			if !ok {
+				continue
+			}
+			if err := decode(values[i], target); err != nil {
+				return fmt.Errorf("field %s: %w", field.Identifier, err)
+			}
+		}
+		return nil
+	}
+
+	return fmt.Errorf("cannot decode composite into %s", rv.Type())
+}
+
+// structField returns the exported struct field for the Cadence field with the given name.
+func structField(rv reflect.Value, name string) (reflect.Value, bool) {
+	t := rv.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		if tag, ok := f.Tag.Lookup("cadence"); ok {
+			if tag == name {
+				return rv.Field(i), true
+			}
+			continue
+		}
+		if strings.EqualFold(f.Name, name) {
+			return rv.Field(i), true
+		}
+	}
+	return reflect.Value{}, false
+}
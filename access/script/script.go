@@ -0,0 +1,194 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2023 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package script builds and executes read-only Cadence scripts using Go values.
+//
+// Arguments are converted to Cadence values using the parameter types declared by the
+// script, so that for example a decimal string can be passed for an UFix64 parameter,
+// and the result is decoded into a Go destination:
+//
+//	var balance string
+//	err := script.New(code).
+//		WithArguments(address).
+//		AtBlockHeight(height).
+//		ExecuteInto(ctx, client, &balance)
+//
+// Scripts are executed through the access.Client interface, so the same code path is used
+// for the gRPC and the HTTP clients.
+package script
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/onflow/cadence"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/access"
+)
+
+// ArgumentError is returned when an argument cannot be converted to a Cadence value.
+type ArgumentError struct {
+	Index int
+	// Parameter is the name of the parameter, empty if the script parameters are unknown.
+	Parameter string
+	Err       error
+}
+
+func (e ArgumentError) Error() string {
+	if e.Parameter != "" {
+		return fmt.Sprintf("invalid argument %d (%s): %v", e.Index, e.Parameter, e.Err)
+	}
+	return fmt.Sprintf("invalid argument %d: %v", e.Index, e.Err)
+}
+
+func (e ArgumentError) Unwrap() error {
+	return e.Err
+}
+
+type targetKind int
+
+const (
+	targetLatest targetKind = iota
+	targetHeight
+	targetBlockID
+)
+
+// A Script is a read-only Cadence script with its arguments and the block it executes at.
+//
+// A script executes at the latest sealed block unless another block is selected.
+type Script struct {
+	code      []byte
+	arguments []any
+
+	target  targetKind
+	height  uint64
+	blockID flow.Identifier
+}
+
+// New returns a script executing the given Cadence code.
+func New(code []byte) *Script {
+	return &Script{code: code}
+}
+
+// WithArguments adds arguments to the script.
+//
+// Arguments can be cadence.Value values or Go values, which are converted according to the
+// declared parameter types, see ToCadence for the supported Go values.
+func (s *Script) WithArguments(arguments ...any) *Script {
+	s.arguments = append(s.arguments, arguments...)
+	return s
+}
+
+// AtLatestBlock executes the script at the latest sealed block.
+func (s *Script) AtLatestBlock() *Script {
+	s.target = targetLatest
+	return s
+}
+
+// AtBlockHeight executes the script at the block with the given height.
+func (s *Script) AtBlockHeight(height uint64) *Script {
+	s.target = targetHeight
+	s.height = height
+	return s
+}
+
+// AtBlockID executes the script at the block with the given ID.
+func (s *Script) AtBlockID(blockID flow.Identifier) *Script {
+	s.target = targetBlockID
+	s.blockID = blockID
+	return s
+}
+
+// Arguments returns the arguments of the script converted to Cadence values.
+//
+// Arguments are converted using the parameter types declared by the script, and a
+// flow.ArgumentCountError is returned if their number differs from the parameters.
+// If the script cannot be parsed, the Cadence types are inferred from the Go types instead.
+func (s *Script) Arguments() ([]cadence.Value, error) {
+	parameters, known := scriptParameters(s.code)
+	return convertArguments(parameters, known, s.arguments)
+}
+
+// scriptParameters returns the parameters of the script, and false if they are unknown
+// because the script cannot be parsed.
+func scriptParameters(code []byte) ([]flow.ScriptParameter, bool) {
+	parameters, err := flow.ParseScriptParameters(code)
+	if err != nil {
+		return nil, false
+	}
+	return parameters, true
+}
+
+// convertArguments converts the arguments to Cadence values using the parameter types if
+// they are known, or by inferring the types otherwise.
+func convertArguments(parameters []flow.ScriptParameter, known bool, arguments []any) ([]cadence.Value, error) {
+	if known && len(parameters) != len(arguments) {
+		return nil, flow.ArgumentCountError{Expected: len(parameters), Actual: len(arguments)}
+	}
+
+	values := make([]cadence.Value, len(arguments))
+	for i, arg := range arguments {
+		var (
+			value cadence.Value
+			name  string
+			err   error
+		)
+		if known {
+			name = parameters[i].Name
+			value, err = ToCadenceType(arg, parameters[i].Type)
+		} else {
+			value, err = ToCadence(arg)
+		}
+		if err != nil {
+			return nil, ArgumentError{Index: i, Parameter: name, Err: err}
+		}
+		values[i] = value
+	}
+
+	return values, nil
+}
+
+// Execute executes the script and returns its result.
+func (s *Script) Execute(ctx context.Context, client access.Client) (cadence.Value, error) {
+	arguments, err := s.Arguments()
+	if err != nil {
+		return nil, err
+	}
+
+	switch s.target {
+	case targetHeight:
+		return client.ExecuteScriptAtBlockHeight(ctx, s.height, s.code, arguments)
+	case targetBlockID:
+		return client.ExecuteScriptAtBlockID(ctx, s.blockID, s.code, arguments)
+	default:
+		return client.ExecuteScriptAtLatestBlock(ctx, s.code, arguments)
+	}
+}
+
+// ExecuteInto executes the script and decodes its result into dest, which must be a pointer.
+//
+// See Decode for the supported destinations.
+func (s *Script) ExecuteInto(ctx context.Context, client access.Client, dest any) error {
+	value, err := s.Execute(ctx, client)
+	if err != nil {
+		return err
+	}
+
+	return Decode(value, dest)
+}
@@ -0,0 +1,238 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2023 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package script
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/onflow/cadence"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/access"
+)
+
+// fakeClient records the script executions, any other method panics.
+type fakeClient struct {
+	access.Client
+	result cadence.Value
+
+	method    string
+	height    uint64
+	blockID   flow.Identifier
+	arguments []cadence.Value
+}
+
+func (c *fakeClient) ExecuteScriptAtLatestBlock(_ context.Context, _ []byte, arguments []cadence.Value) (cadence.Value, error) {
+	c.method, c.arguments = "latest", arguments
+	return c.result, nil
+}
+
+func (c *fakeClient) ExecuteScriptAtBlockHeight(_ context.Context, height uint64, _ []byte, arguments []cadence.Value) (cadence.Value, error) {
+	c.method, c.height, c.arguments = "height", height, arguments
+	return c.result, nil
+}
+
+func (c *fakeClient) ExecuteScriptAtBlockID(_ context.Context, blockID flow.Identifier, _ []byte, arguments []cadence.Value) (cadence.Value, error) {
+	c.method, c.blockID, c.arguments = "id", blockID, arguments
+	return c.result, nil
+}
+
+const balanceScript = `
+pub fun main(account: Address, amount: UFix64, limit: UInt8, tags: [String], weights: {String: UInt64}, memo: String?): UFix64 {
+	return amount
+}
+`
+
+func mustUFix64(t *testing.T, s string) cadence.UFix64 {
+	v, err := cadence.NewUFix64(s)
+	require.NoError(t, err)
+	return v
+}
+
+func TestScript_Execute(t *testing.T) {
+	ctx := context.Background()
+	address := flow.HexToAddress("01")
+
+	client := &fakeClient{result: mustUFix64(t, "1.5")}
+
+	s := New([]byte(balanceScript)).
+		WithArguments(address, "2.5", 7, []string{"a", "b"}, map[string]uint64{"y": 2, "x": 1}, nil)
+
+	var result string
+	require.NoError(t, s.ExecuteInto(ctx, client, &result))
+	assert.Equal(t, "1.50000000", result)
+	assert.Equal(t, "latest", client.method)
+
+	assert.Equal(t, []cadence.Value{
+		cadence.NewAddress(address),
+		mustUFix64(t, "2.5"),
+		cadence.NewUInt8(7),
+		cadence.NewArray([]cadence.Value{cadence.String("a"), cadence.String("b")}),
+		cadence.NewDictionary([]cadence.KeyValuePair{
+			{Key: cadence.String("x"), Value: cadence.NewUInt64(1)},
+			{Key: cadence.String("y"), Value: cadence.NewUInt64(2)},
+		}),
+		cadence.NewOptional(nil),
+	}, client.arguments)
+
+	_, err := s.AtBlockHeight(10).Execute(ctx, client)
+	require.NoError(t, err)
+	assert.Equal(t, "height", client.method)
+	assert.Equal(t, uint64(10), client.height)
+
+	_, err = s.AtBlockID(flow.Identifier{1}).Execute(ctx, client)
+	require.NoError(t, err)
+	assert.Equal(t, "id", client.method)
+	assert.Equal(t, flow.Identifier{1}, client.blockID)
+
+	t.Run("invalid argument", func(t *testing.T) {
+		_, err := New([]byte(balanceScript)).
+			WithArguments(address, 2.5, 7, []string{}, map[string]uint64{}, nil).
+			Execute(ctx, client)
+
+		var argErr ArgumentError
+		require.ErrorAs(t, err, &argErr)
+		assert.Equal(t, 1, argErr.Index)
+		assert.Equal(t, "amount", argErr.Parameter)
+	})
+
+	t.Run("argument count", func(t *testing.T) {
+		client := &fakeClient{}
+		_, err := New([]byte(balanceScript)).
+			WithArguments(address, "1.0").
+			Execute(ctx, client)
+
+		assert.Equal(t, flow.ArgumentCountError{Expected: 6, Actual: 2}, err)
+		assert.Empty(t, client.method)
+
+		// the count of a script without parameters is known too
+		_, err = New([]byte(`pub fun main(): Int { return 1 }`)).WithArguments(1).Execute(ctx, client)
+		assert.Equal(t, flow.ArgumentCountError{Expected: 0, Actual: 1}, err)
+	})
+
+	t.Run("invalid address", func(t *testing.T) {
+		_, err := New([]byte(balanceScript)).
+			WithArguments("0xZZ", "1.0", 1, []string{}, map[string]uint64{}, nil).
+			Execute(ctx, client)
+
+		var argErr ArgumentError
+		require.ErrorAs(t, err, &argErr)
+		assert.Equal(t, 0, argErr.Index)
+		assert.Equal(t, "account", argErr.Parameter)
+	})
+
+	t.Run("out of range", func(t *testing.T) {
+		_, err := New([]byte(balanceScript)).
+			WithArguments(address, "1.0", 256, []string{}, map[string]uint64{}, nil).
+			Execute(ctx, client)
+
+		var argErr ArgumentError
+		require.ErrorAs(t, err, &argErr)
+		assert.Equal(t, "limit", argErr.Parameter)
+	})
+}
+
+func TestToCadence(t *testing.T) {
+	value, err := ToCadence(map[string][]int{"a": {1, 2}})
+	require.NoError(t, err)
+	assert.Equal(t, cadence.NewDictionary([]cadence.KeyValuePair{
+		{Key: cadence.String("a"), Value: cadence.NewArray([]cadence.Value{cadence.NewInt(1), cadence.NewInt(2)})},
+	}), value)
+
+	n := uint32(3)
+	value, err = ToCadence(&n)
+	require.NoError(t, err)
+	assert.Equal(t, cadence.NewOptional(cadence.NewUInt32(3)), value)
+
+	_, err = ToCadence(1.5)
+	assert.Error(t, err)
+
+	value, err = ToCadenceType("0x01", "Address")
+	require.NoError(t, err)
+	assert.Equal(t, cadence.NewAddress(flow.HexToAddress("01")), value)
+
+	value, err = ToCadenceType("340282366920938463463374607431768211455", "UInt128")
+	require.NoError(t, err)
+	assert.Equal(t, "340282366920938463463374607431768211455", value.String())
+
+	_, err = ToCadenceType(-1, "UInt64")
+	assert.Error(t, err)
+}
+
+func TestDecode(t *testing.T) {
+	t.Run("composite", func(t *testing.T) {
+		type Info struct {
+			Address flow.Address
+			Balance string `cadence:"balance"`
+			Keys    []uint32
+			Label   *string
+			Extra   int
+		}
+
+		value := cadence.NewStruct([]cadence.Value{
+			cadence.NewAddress(flow.HexToAddress("02")),
+			mustUFix64(t, "3.0"),
+			cadence.NewArray([]cadence.Value{cadence.NewUInt32(1)}),
+			cadence.NewOptional(cadence.String("main")),
+		}).WithType(&cadence.StructType{
+			QualifiedIdentifier: "Info",
+			Fields: []cadence.Field{
+				{Identifier: "address", Type: cadence.AddressType{}},
+				{Identifier: "balance", Type: cadence.UFix64Type{}},
+				{Identifier: "keys", Type: cadence.NewVariableSizedArrayType(cadence.UInt32Type{})},
+				{Identifier: "label", Type: cadence.NewOptionalType(cadence.StringType{})},
+			},
+		})
+
+		var info Info
+		require.NoError(t, Decode(value, &info))
+		assert.Equal(t, flow.HexToAddress("02"), info.Address)
+		assert.Equal(t, "3.00000000", info.Balance)
+		assert.Equal(t, []uint32{1}, info.Keys)
+		require.NotNil(t, info.Label)
+		assert.Equal(t, "main", *info.Label)
+
+		var fields map[string]cadence.Value
+		require.NoError(t, Decode(value, &fields))
+		assert.Len(t, fields, 4)
+	})
+
+	t.Run("integers", func(t *testing.T) {
+		var small uint8
+		assert.Error(t, Decode(cadence.NewInt(300), &small))
+
+		var b big.Int
+		require.NoError(t, Decode(cadence.NewInt(300), &b))
+		assert.Equal(t, int64(300), b.Int64())
+
+		var n *int
+		require.NoError(t, Decode(cadence.NewOptional(nil), &n))
+		assert.Nil(t, n)
+	})
+
+	t.Run("mismatch", func(t *testing.T) {
+		var b bool
+		assert.Error(t, Decode(cadence.String("true"), &b))
+		assert.Error(t, Decode(cadence.String("true"), b))
+	})
+}
@@ -0,0 +1,134 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2023 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package snapshot provides reads pinned to a single sealed block.
+//
+// Reads made through an access client at the "latest" block may each observe a different
+// sealed block. A Snapshot is obtained once from a sealed block header, and all its reads
+// target that block, so that a workflow making several reads sees one consistent state:
+//
+//	snap, err := snapshot.Latest(ctx, client)
+//	...
+//	balance, err := snap.ExecuteScript(ctx, balanceScript, args)
+//	supply, err := snap.ExecuteScript(ctx, supplyScript, nil)
+package snapshot
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/onflow/cadence"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/access"
+	"github.com/onflow/flow-go-sdk/access/script"
+)
+
+// A Snapshot reads the state of the chain at a single sealed block.
+type Snapshot struct {
+	client access.Client
+	header *flow.BlockHeader
+}
+
+// Latest returns a snapshot pinned to the latest sealed block.
+func Latest(ctx context.Context, client access.Client) (*Snapshot, error) {
+	header, err := client.GetLatestBlockHeader(ctx, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the latest sealed block header: %w", err)
+	}
+
+	return New(client, header), nil
+}
+
+// AtHeight returns a snapshot pinned to the block at the given height.
+func AtHeight(ctx context.Context, client access.Client, height uint64) (*Snapshot, error) {
+	header, err := client.GetBlockHeaderByHeight(ctx, height)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the block header at height %d: %w", height, err)
+	}
+
+	return New(client, header), nil
+}
+
+// New returns a snapshot pinned to the block with the given header.
+func New(client access.Client, header *flow.BlockHeader) *Snapshot {
+	return &Snapshot{
+		client: client,
+		header: header,
+	}
+}
+
+// Header returns the header of the block the snapshot is pinned to.
+func (s *Snapshot) Header() *flow.BlockHeader {
+	return s.header
+}
+
+// Height returns the height of the block the snapshot is pinned to.
+func (s *Snapshot) Height() uint64 {
+	return s.header.Height
+}
+
+// BlockID returns the ID of the block the snapshot is pinned to.
+func (s *Snapshot) BlockID() flow.Identifier {
+	return s.header.ID
+}
+
+// ExecuteScript executes a read-only Cadence script at the snapshot block.
+func (s *Snapshot) ExecuteScript(ctx context.Context, code []byte, arguments []cadence.Value) (cadence.Value, error) {
+	return s.client.ExecuteScriptAtBlockID(ctx, s.header.ID, code, arguments)
+}
+
+// Script returns a script builder executing at the snapshot block.
+func (s *Snapshot) Script(code []byte) *script.Script {
+	return script.New(code).AtBlockID(s.header.ID)
+}
+
+// Batch returns a batch executing scripts at the snapshot height.
+func (s *Snapshot) Batch(code []byte, opts ...script.BatchOption) *script.Batch {
+	return script.NewBatch(code, s.header.Height, opts...)
+}
+
+// GetBlock returns the full block the snapshot is pinned to.
+func (s *Snapshot) GetBlock(ctx context.Context) (*flow.Block, error) {
+	return s.client.GetBlockByID(ctx, s.header.ID)
+}
+
+// GetExecutionResult returns the execution result of the snapshot block.
+func (s *Snapshot) GetExecutionResult(ctx context.Context) (*flow.ExecutionResult, error) {
+	return s.client.GetExecutionResultForBlockID(ctx, s.header.ID)
+}
+
+// GetTransactionResults returns the results of the transactions of the snapshot block.
+func (s *Snapshot) GetTransactionResults(ctx context.Context) ([]*flow.TransactionResult, error) {
+	return s.client.GetTransactionResultsByBlockID(ctx, s.header.ID)
+}
+
+// GetEvents returns the events with the given type emitted in the snapshot block.
+func (s *Snapshot) GetEvents(ctx context.Context, eventType string) ([]flow.BlockEvents, error) {
+	return s.client.GetEventsForBlockIDs(ctx, eventType, []flow.Identifier{s.header.ID})
+}
+
+// GetEventsSince returns the events with the given type emitted between the start height
+// and the snapshot height, inclusive.
+func (s *Snapshot) GetEventsSince(ctx context.Context, eventType string, startHeight uint64) ([]flow.BlockEvents, error) {
+	if startHeight > s.header.Height {
+		return nil, fmt.Errorf("start height %d is above the snapshot height %d", startHeight, s.header.Height)
+	}
+
+	return s.client.GetEventsForHeightRange(ctx, eventType, startHeight, s.header.Height)
+}
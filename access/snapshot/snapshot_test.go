@@ -0,0 +1,106 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2023 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package snapshot
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/onflow/cadence"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/access"
+)
+
+// fakeClient records the block targeted by each read, any other method panics.
+type fakeClient struct {
+	access.Client
+	latest *flow.BlockHeader
+
+	calls []string
+}
+
+func (c *fakeClient) GetLatestBlockHeader(_ context.Context, isSealed bool) (*flow.BlockHeader, error) {
+	c.calls = append(c.calls, "latest")
+	// the latest header moves on after the snapshot is taken
+	header := *c.latest
+	c.latest = &flow.BlockHeader{ID: flow.Identifier{byte(header.Height + 1)}, Height: header.Height + 1}
+	return &header, nil
+}
+
+func (c *fakeClient) ExecuteScriptAtBlockID(_ context.Context, blockID flow.Identifier, _ []byte, _ []cadence.Value) (cadence.Value, error) {
+	c.calls = append(c.calls, "script@"+blockID.String()[:2])
+	return cadence.NewInt(1), nil
+}
+
+func (c *fakeClient) ExecuteScriptAtBlockHeight(_ context.Context, height uint64, _ []byte, _ []cadence.Value) (cadence.Value, error) {
+	c.calls = append(c.calls, fmt.Sprintf("script@%d", height))
+	return cadence.NewInt(1), nil
+}
+
+func (c *fakeClient) GetEventsForBlockIDs(_ context.Context, _ string, blockIDs []flow.Identifier) ([]flow.BlockEvents, error) {
+	c.calls = append(c.calls, "events@"+blockIDs[0].String()[:2])
+	return nil, nil
+}
+
+func (c *fakeClient) GetEventsForHeightRange(_ context.Context, _ string, start uint64, end uint64) ([]flow.BlockEvents, error) {
+	c.calls = append(c.calls, fmt.Sprintf("events@%d-%d", start, end))
+	return nil, nil
+}
+
+func TestSnapshot(t *testing.T) {
+	ctx := context.Background()
+	client := &fakeClient{latest: &flow.BlockHeader{ID: flow.Identifier{0x0a}, Height: 10}}
+
+	snap, err := Latest(ctx, client)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(10), snap.Height())
+	assert.Equal(t, flow.Identifier{0x0a}, snap.BlockID())
+
+	_, err = snap.ExecuteScript(ctx, []byte(`pub fun main(): Int { return 1 }`), nil)
+	require.NoError(t, err)
+
+	var n int
+	require.NoError(t, snap.Script([]byte(`pub fun main(): Int { return 1 }`)).ExecuteInto(ctx, client, &n))
+	assert.Equal(t, 1, n)
+
+	results := snap.Batch([]byte(`pub fun main(n: Int): Int { return n }`)).Execute(ctx, client, [][]any{{1}})
+	require.NoError(t, results[0].Err)
+
+	_, err = snap.GetEvents(ctx, "A.0x1.Foo.Bar")
+	require.NoError(t, err)
+
+	_, err = snap.GetEventsSince(ctx, "A.0x1.Foo.Bar", 5)
+	require.NoError(t, err)
+
+	_, err = snap.GetEventsSince(ctx, "A.0x1.Foo.Bar", 11)
+	assert.Error(t, err)
+
+	assert.Equal(t, []string{
+		"latest",
+		"script@0a",
+		"script@0a",
+		"script@10",
+		"events@0a",
+		"events@5-10",
+	}, client.calls)
+}
@@ -0,0 +1,467 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2023 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package telemetry provides OpenTelemetry instrumentation for the access clients.
+//
+// The instrumented client wraps any access.Client, so the gRPC and HTTP clients are
+// instrumented in exactly the same way. Every Access API call produces a client span
+// carrying the method name, the block height or ID and the transaction ID involved in
+// the call, and is recorded in a latency histogram. Failed calls are classified and
+// counted. Instrumentation is opt-in: nothing is emitted unless the client is wrapped.
+//
+// The telemetrytest package provides in-memory exporters to inspect the emitted
+// spans and metrics in tests.
+package telemetry
+
+import (
+	"context"
+	"time"
+
+	"github.com/onflow/cadence"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/global"
+	"go.opentelemetry.io/otel/metric/instrument"
+	"go.opentelemetry.io/otel/metric/unit"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/access"
+)
+
+// InstrumentationName is the name of the tracer and meter used by the instrumented client.
+const InstrumentationName = "github.com/onflow/flow-go-sdk/access"
+
+// spanPrefix is prepended to the method name to build the span name, following the RPC conventions.
+const spanPrefix = "flow.access.AccessAPI/"
+
+// Attribute keys set on the spans and metrics.
+const (
+	MethodKey        = attribute.Key("flow.access.method")
+	ErrorClassKey    = attribute.Key("flow.access.error_class")
+	BlockIDKey       = attribute.Key("flow.block.id")
+	BlockHeightKey   = attribute.Key("flow.block.height")
+	BlockSealedKey   = attribute.Key("flow.block.sealed")
+	StartHeightKey   = attribute.Key("flow.block.start_height")
+	EndHeightKey     = attribute.Key("flow.block.end_height")
+	BlockCountKey    = attribute.Key("flow.block.count")
+	CollectionIDKey  = attribute.Key("flow.collection.id")
+	TransactionIDKey = attribute.Key("flow.transaction.id")
+	EventTypeKey     = attribute.Key("flow.event.type")
+)
+
+// Metric names recorded by the instrumented client.
+const (
+	DurationMetric = "flow.access.request.duration"
+	ErrorsMetric   = "flow.access.request.errors"
+)
+
+// Option configures the instrumented client.
+type Option func(*config)
+
+type config struct {
+	tracerProvider trace.TracerProvider
+	meterProvider  metric.MeterProvider
+}
+
+// WithTracerProvider sets the tracer provider, the global provider is used by default.
+func WithTracerProvider(provider trace.TracerProvider) Option {
+	return func(c *config) {
+		c.tracerProvider = provider
+	}
+}
+
+// WithMeterProvider sets the meter provider, the global provider is used by default.
+func WithMeterProvider(provider metric.MeterProvider) Option {
+	return func(c *config) {
+		c.meterProvider = provider
+	}
+}
+
+// Client is an access.Client instrumented with OpenTelemetry.
+type Client struct {
+	client   access.Client
+	tracer   trace.Tracer
+	duration instrument.Float64Histogram
+	errors   instrument.Int64Counter
+}
+
+var _ access.Client = (*Client)(nil)
+
+// NewClient wraps the provided client with OpenTelemetry instrumentation.
+func NewClient(client access.Client, opts ...Option) (*Client, error) {
+	cfg := config{
+		tracerProvider: otel.GetTracerProvider(),
+		meterProvider:  global.MeterProvider(),
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	meter := cfg.meterProvider.Meter(InstrumentationName)
+
+	duration, err := meter.Float64Histogram(
+		DurationMetric,
+		instrument.WithDescription("Duration of the Access API requests."),
+		instrument.WithUnit(string(unit.Milliseconds)),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	errors, err := meter.Int64Counter(
+		ErrorsMetric,
+		instrument.WithDescription("Number of failed Access API requests."),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Client{
+		client:   client,
+		tracer:   cfg.tracerProvider.Tracer(InstrumentationName),
+		duration: duration,
+		errors:   errors,
+	}, nil
+}
+
+// observe wraps the call in a span and records its latency and outcome.
+func (c *Client) observe(
+	ctx context.Context,
+	method string,
+	attrs []attribute.KeyValue,
+	call func(ctx context.Context, span trace.Span) error,
+) error {
+	ctx, span := c.tracer.Start(
+		ctx,
+		spanPrefix+method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(append(attrs, MethodKey.String(method))...),
+	)
+	defer span.End()
+
+	start := time.Now()
+	err := call(ctx, span)
+	elapsed := float64(time.Since(start)) / float64(time.Millisecond)
+
+	metricAttrs := []attribute.KeyValue{MethodKey.String(method)}
+	if err != nil {
+		class := ClassifyError(err)
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		span.SetAttributes(ErrorClassKey.String(class))
+
+		metricAttrs = append(metricAttrs, ErrorClassKey.String(class))
+		c.errors.Add(ctx, 1, metricAttrs...)
+	}
+	c.duration.Record(ctx, elapsed, metricAttrs...)
+
+	return err
+}
+
+func headerAttributes(header *flow.BlockHeader) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		BlockIDKey.String(header.ID.String()),
+		BlockHeightKey.Int64(int64(header.Height)),
+	}
+}
+
+func (c *Client) Ping(ctx context.Context) error {
+	return c.observe(ctx, "Ping", nil, func(ctx context.Context, _ trace.Span) error {
+		return c.client.Ping(ctx)
+	})
+}
+
+func (c *Client) GetLatestBlockHeader(ctx context.Context, isSealed bool) (*flow.BlockHeader, error) {
+	var header *flow.BlockHeader
+	attrs := []attribute.KeyValue{BlockSealedKey.Bool(isSealed)}
+
+	err := c.observe(ctx, "GetLatestBlockHeader", attrs, func(ctx context.Context, span trace.Span) error {
+		var err error
+		header, err = c.client.GetLatestBlockHeader(ctx, isSealed)
+		if err == nil {
+			span.SetAttributes(headerAttributes(header)...)
+		}
+		return err
+	})
+	return header, err
+}
+
+func (c *Client) GetBlockHeaderByID(ctx context.Context, blockID flow.Identifier) (*flow.BlockHeader, error) {
+	var header *flow.BlockHeader
+	attrs := []attribute.KeyValue{BlockIDKey.String(blockID.String())}
+
+	err := c.observe(ctx, "GetBlockHeaderByID", attrs, func(ctx context.Context, span trace.Span) error {
+		var err error
+		header, err = c.client.GetBlockHeaderByID(ctx, blockID)
+		if err == nil {
+			span.SetAttributes(BlockHeightKey.Int64(int64(header.Height)))
+		}
+		return err
+	})
+	return header, err
+}
+
+func (c *Client) GetBlockHeaderByHeight(ctx context.Context, height uint64) (*flow.BlockHeader, error) {
+	var header *flow.BlockHeader
+	attrs := []attribute.KeyValue{BlockHeightKey.Int64(int64(height))}
+
+	err := c.observe(ctx, "GetBlockHeaderByHeight", attrs, func(ctx context.Context, span trace.Span) error {
+		var err error
+		header, err = c.client.GetBlockHeaderByHeight(ctx, height)
+		if err == nil {
+			span.SetAttributes(BlockIDKey.String(header.ID.String()))
+		}
+		return err
+	})
+	return header, err
+}
+
+func (c *Client) GetLatestBlock(ctx context.Context, isSealed bool) (*flow.Block, error) {
+	var block *flow.Block
+	attrs := []attribute.KeyValue{BlockSealedKey.Bool(isSealed)}
+
+	err := c.observe(ctx, "GetLatestBlock", attrs, func(ctx context.Context, span trace.Span) error {
+		var err error
+		block, err = c.client.GetLatestBlock(ctx, isSealed)
+		if err == nil {
+			span.SetAttributes(headerAttributes(&block.BlockHeader)...)
+		}
+		return err
+	})
+	return block, err
+}
+
+func (c *Client) GetBlockByID(ctx context.Context, blockID flow.Identifier) (*flow.Block, error) {
+	var block *flow.Block
+	attrs := []attribute.KeyValue{BlockIDKey.String(blockID.String())}
+
+	err := c.observe(ctx, "GetBlockByID", attrs, func(ctx context.Context, span trace.Span) error {
+		var err error
+		block, err = c.client.GetBlockByID(ctx, blockID)
+		if err == nil {
+			span.SetAttributes(BlockHeightKey.Int64(int64(block.Height)))
+		}
+		return err
+	})
+	return block, err
+}
+
+func (c *Client) GetBlockByHeight(ctx context.Context, height uint64) (*flow.Block, error) {
+	var block *flow.Block
+	attrs := []attribute.KeyValue{BlockHeightKey.Int64(int64(height))}
+
+	err := c.observe(ctx, "GetBlockByHeight", attrs, func(ctx context.Context, span trace.Span) error {
+		var err error
+		block, err = c.client.GetBlockByHeight(ctx, height)
+		if err == nil {
+			span.SetAttributes(BlockIDKey.String(block.ID.String()))
+		}
+		return err
+	})
+	return block, err
+}
+
+func (c *Client) GetCollection(ctx context.Context, colID flow.Identifier) (*flow.Collection, error) {
+	var collection *flow.Collection
+	attrs := []attribute.KeyValue{CollectionIDKey.String(colID.String())}
+
+	err := c.observe(ctx, "GetCollection", attrs, func(ctx context.Context, _ trace.Span) error {
+		var err error
+		collection, err = c.client.GetCollection(ctx, colID)
+		return err
+	})
+	return collection, err
+}
+
+func (c *Client) SendTransaction(ctx context.Context, tx flow.Transaction) error {
+	attrs := []attribute.KeyValue{BlockIDKey.String(tx.ReferenceBlockID.String())}
+
+	return c.observe(ctx, "SendTransaction", attrs, func(ctx context.Context, _ trace.Span) error {
+		return c.client.SendTransaction(ctx, tx)
+	})
+}
+
+func (c *Client) GetTransaction(ctx context.Context, txID flow.Identifier) (*flow.Transaction, error) {
+	var tx *flow.Transaction
+	attrs := []attribute.KeyValue{TransactionIDKey.String(txID.String())}
+
+	err := c.observe(ctx, "GetTransaction", attrs, func(ctx context.Context, _ trace.Span) error {
+		var err error
+		tx, err = c.client.GetTransaction(ctx, txID)
+		return err
+	})
+	return tx, err
+}
+
+func (c *Client) GetTransactionsByBlockID(ctx context.Context, blockID flow.Identifier) ([]*flow.Transaction, error) {
+	var txs []*flow.Transaction
+	attrs := []attribute.KeyValue{BlockIDKey.String(blockID.String())}
+
+	err := c.observe(ctx, "GetTransactionsByBlockID", attrs, func(ctx context.Context, _ trace.Span) error {
+		var err error
+		txs, err = c.client.GetTransactionsByBlockID(ctx, blockID)
+		return err
+	})
+	return txs, err
+}
+
+func (c *Client) GetTransactionResult(ctx context.Context, txID flow.Identifier) (*flow.TransactionResult, error) {
+	var result *flow.TransactionResult
+	attrs := []attribute.KeyValue{TransactionIDKey.String(txID.String())}
+
+	err := c.observe(ctx, "GetTransactionResult", attrs, func(ctx context.Context, span trace.Span) error {
+		var err error
+		result, err = c.client.GetTransactionResult(ctx, txID)
+		if err == nil {
+			span.SetAttributes(BlockIDKey.String(result.BlockID.String()))
+		}
+		return err
+	})
+	return result, err
+}
+
+func (c *Client) GetTransactionResultsByBlockID(ctx context.Context, blockID flow.Identifier) ([]*flow.TransactionResult, error) {
+	var results []*flow.TransactionResult
+	attrs := []attribute.KeyValue{BlockIDKey.String(blockID.String())}
+
+	err := c.observe(ctx, "GetTransactionResultsByBlockID", attrs, func(ctx context.Context, _ trace.Span) error {
+		var err error
+		results, err = c.client.GetTransactionResultsByBlockID(ctx, blockID)
+		return err
+	})
+	return results, err
+}
+
+func (c *Client) ExecuteScriptAtLatestBlock(
+	ctx context.Context,
+	script []byte,
+	arguments []cadence.Value,
+) (cadence.Value, error) {
+	var value cadence.Value
+
+	err := c.observe(ctx, "ExecuteScriptAtLatestBlock", nil, func(ctx context.Context, _ trace.Span) error {
+		var err error
+		value, err = c.client.ExecuteScriptAtLatestBlock(ctx, script, arguments)
+		return err
+	})
+	return value, err
+}
+
+func (c *Client) ExecuteScriptAtBlockID(
+	ctx context.Context,
+	blockID flow.Identifier,
+	script []byte,
+	arguments []cadence.Value,
+) (cadence.Value, error) {
+	var value cadence.Value
+	attrs := []attribute.KeyValue{BlockIDKey.String(blockID.String())}
+
+	err := c.observe(ctx, "ExecuteScriptAtBlockID", attrs, func(ctx context.Context, _ trace.Span) error {
+		var err error
+		value, err = c.client.ExecuteScriptAtBlockID(ctx, blockID, script, arguments)
+		return err
+	})
+	return value, err
+}
+
+func (c *Client) ExecuteScriptAtBlockHeight(
+	ctx context.Context,
+	height uint64,
+	script []byte,
+	arguments []cadence.Value,
+) (cadence.Value, error) {
+	var value cadence.Value
+	attrs := []attribute.KeyValue{BlockHeightKey.Int64(int64(height))}
+
+	err := c.observe(ctx, "ExecuteScriptAtBlockHeight", attrs, func(ctx context.Context, _ trace.Span) error {
+		var err error
+		value, err = c.client.ExecuteScriptAtBlockHeight(ctx, height, script, arguments)
+		return err
+	})
+	return value, err
+}
+
+func (c *Client) GetEventsForHeightRange(
+	ctx context.Context,
+	eventType string,
+	startHeight uint64,
+	endHeight uint64,
+) ([]flow.BlockEvents, error) {
+	var events []flow.BlockEvents
+	attrs := []attribute.KeyValue{
+		EventTypeKey.String(eventType),
+		StartHeightKey.Int64(int64(startHeight)),
+		EndHeightKey.Int64(int64(endHeight)),
+	}
+
+	err := c.observe(ctx, "GetEventsForHeightRange", attrs, func(ctx context.Context, _ trace.Span) error {
+		var err error
+		events, err = c.client.GetEventsForHeightRange(ctx, eventType, startHeight, endHeight)
+		return err
+	})
+	return events, err
+}
+
+func (c *Client) GetEventsForBlockIDs(
+	ctx context.Context,
+	eventType string,
+	blockIDs []flow.Identifier,
+) ([]flow.BlockEvents, error) {
+	var events []flow.BlockEvents
+	attrs := []attribute.KeyValue{
+		EventTypeKey.String(eventType),
+		BlockCountKey.Int(len(blockIDs)),
+	}
+
+	err := c.observe(ctx, "GetEventsForBlockIDs", attrs, func(ctx context.Context, _ trace.Span) error {
+		var err error
+		events, err = c.client.GetEventsForBlockIDs(ctx, eventType, blockIDs)
+		return err
+	})
+	return events, err
+}
+
+func (c *Client) GetLatestProtocolStateSnapshot(ctx context.Context) ([]byte, error) {
+	var snapshot []byte
+
+	err := c.observe(ctx, "GetLatestProtocolStateSnapshot", nil, func(ctx context.Context, _ trace.Span) error {
+		var err error
+		snapshot, err = c.client.GetLatestProtocolStateSnapshot(ctx)
+		return err
+	})
+	return snapshot, err
+}
+
+func (c *Client) GetExecutionResultForBlockID(ctx context.Context, blockID flow.Identifier) (*flow.ExecutionResult, error) {
+	var result *flow.ExecutionResult
+	attrs := []attribute.KeyValue{BlockIDKey.String(blockID.String())}
+
+	err := c.observe(ctx, "GetExecutionResultForBlockID", attrs, func(ctx context.Context, _ trace.Span) error {
+		var err error
+		result, err = c.client.GetExecutionResultForBlockID(ctx, blockID)
+		return err
+	})
+	return result, err
+}
+
+func (c *Client) Close() error {
+	return c.client.Close()
+}
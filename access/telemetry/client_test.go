@@ -0,0 +1,155 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2023 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package telemetry_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"google.golang.org/grpc/status"
+
+	grpcCodes "google.golang.org/grpc/codes"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/access"
+	accessHTTP "github.com/onflow/flow-go-sdk/access/http"
+	"github.com/onflow/flow-go-sdk/access/telemetry"
+	"github.com/onflow/flow-go-sdk/access/telemetry/telemetrytest"
+)
+
+// fakeClient implements the methods used by the tests, any other method panics.
+type fakeClient struct {
+	access.Client
+	err error
+}
+
+func (f *fakeClient) GetBlockByHeight(_ context.Context, height uint64) (*flow.Block, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &flow.Block{BlockHeader: flow.BlockHeader{Height: height}}, nil
+}
+
+func (f *fakeClient) GetTransaction(_ context.Context, txID flow.Identifier) (*flow.Transaction, error) {
+	return nil, f.err
+}
+
+func attributeValue(attrs []attribute.KeyValue, key attribute.Key) (attribute.Value, bool) {
+	for _, attr := range attrs {
+		if attr.Key == key {
+			return attr.Value, true
+		}
+	}
+	return attribute.Value{}, false
+}
+
+func TestClient_Span(t *testing.T) {
+	ctx := context.Background()
+	recorder := telemetrytest.NewRecorder()
+
+	client, err := telemetry.NewClient(&fakeClient{}, recorder.Options()...)
+	require.NoError(t, err)
+
+	_, err = client.GetBlockByHeight(ctx, 42)
+	require.NoError(t, err)
+
+	spans := recorder.Spans()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "flow.access.AccessAPI/GetBlockByHeight", spans[0].Name)
+	assert.Equal(t, codes.Unset, spans[0].Status.Code)
+
+	method, ok := attributeValue(spans[0].Attributes, telemetry.MethodKey)
+	require.True(t, ok)
+	assert.Equal(t, "GetBlockByHeight", method.AsString())
+
+	height, ok := attributeValue(spans[0].Attributes, telemetry.BlockHeightKey)
+	require.True(t, ok)
+	assert.Equal(t, int64(42), height.AsInt64())
+
+	duration, ok, err := recorder.Metric(ctx, telemetry.DurationMetric)
+	require.NoError(t, err)
+	require.True(t, ok)
+	histogram := duration.Data.(metricdata.Histogram)
+	require.Len(t, histogram.DataPoints, 1)
+	assert.Equal(t, uint64(1), histogram.DataPoints[0].Count)
+
+	_, ok, err = recorder.Metric(ctx, telemetry.ErrorsMetric)
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestClient_Error(t *testing.T) {
+	ctx := context.Background()
+	recorder := telemetrytest.NewRecorder()
+
+	fake := &fakeClient{err: status.Error(grpcCodes.NotFound, "not found")}
+	client, err := telemetry.NewClient(fake, recorder.Options()...)
+	require.NoError(t, err)
+
+	txID := flow.HexToID("ab")
+	_, err = client.GetTransaction(ctx, txID)
+	require.Error(t, err)
+
+	spans := recorder.Spans()
+	require.Len(t, spans, 1)
+	assert.Equal(t, codes.Error, spans[0].Status.Code)
+
+	id, ok := attributeValue(spans[0].Attributes, telemetry.TransactionIDKey)
+	require.True(t, ok)
+	assert.Equal(t, txID.String(), id.AsString())
+
+	class, ok := attributeValue(spans[0].Attributes, telemetry.ErrorClassKey)
+	require.True(t, ok)
+	assert.Equal(t, telemetry.ErrorClassNotFound, class.AsString())
+
+	errs, ok, err := recorder.Metric(ctx, telemetry.ErrorsMetric)
+	require.NoError(t, err)
+	require.True(t, ok)
+	sum := errs.Data.(metricdata.Sum[int64])
+	require.Len(t, sum.DataPoints, 1)
+	assert.Equal(t, int64(1), sum.DataPoints[0].Value)
+}
+
+func TestClassifyError(t *testing.T) {
+	tests := []struct {
+		err   error
+		class string
+	}{
+		{context.Canceled, telemetry.ErrorClassCanceled},
+		{context.DeadlineExceeded, telemetry.ErrorClassTimeout},
+		{status.Error(grpcCodes.Unavailable, ""), telemetry.ErrorClassUnavailable},
+		{status.Error(grpcCodes.InvalidArgument, ""), telemetry.ErrorClassInvalidArgument},
+		{status.Error(grpcCodes.ResourceExhausted, ""), telemetry.ErrorClassRateLimited},
+		{accessHTTP.HTTPError{Code: http.StatusNotFound}, telemetry.ErrorClassNotFound},
+		{accessHTTP.HTTPError{Code: http.StatusTooManyRequests}, telemetry.ErrorClassRateLimited},
+		{accessHTTP.HTTPError{Code: http.StatusBadRequest}, telemetry.ErrorClassInvalidArgument},
+		{accessHTTP.HTTPError{Code: http.StatusServiceUnavailable}, telemetry.ErrorClassUnavailable},
+		{accessHTTP.HTTPError{Code: http.StatusInternalServerError}, telemetry.ErrorClassInternal},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.class, telemetry.ClassifyError(tt.err), tt.err.Error())
+	}
+}
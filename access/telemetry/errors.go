@@ -0,0 +1,106 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2023 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	accessHTTP "github.com/onflow/flow-go-sdk/access/http"
+)
+
+// Error classes recorded in the ErrorClassKey attribute.
+const (
+	ErrorClassCanceled        = "canceled"
+	ErrorClassTimeout         = "timeout"
+	ErrorClassInvalidArgument = "invalid_argument"
+	ErrorClassNotFound        = "not_found"
+	ErrorClassRateLimited     = "rate_limited"
+	ErrorClassUnavailable     = "unavailable"
+	ErrorClassInternal        = "internal"
+	ErrorClassUnknown         = "unknown"
+)
+
+// ClassifyError maps an error returned by an access client to a low cardinality class.
+//
+// Both gRPC status errors and HTTP errors are mapped to the same classes, so the metrics
+// of the gRPC and HTTP clients can be compared.
+func ClassifyError(err error) string {
+	switch {
+	case errors.Is(err, context.Canceled):
+		return ErrorClassCanceled
+	case errors.Is(err, context.DeadlineExceeded):
+		return ErrorClassTimeout
+	}
+
+	var httpErr accessHTTP.HTTPError
+	if errors.As(err, &httpErr) {
+		return classifyHTTPStatus(httpErr.Code)
+	}
+
+	if s, ok := status.FromError(err); ok {
+		return classifyGRPCCode(s.Code())
+	}
+
+	return ErrorClassUnknown
+}
+
+func classifyGRPCCode(code codes.Code) string {
+	switch code {
+	case codes.Canceled:
+		return ErrorClassCanceled
+	case codes.DeadlineExceeded:
+		return ErrorClassTimeout
+	case codes.InvalidArgument, codes.FailedPrecondition, codes.OutOfRange, codes.AlreadyExists:
+		return ErrorClassInvalidArgument
+	case codes.NotFound:
+		return ErrorClassNotFound
+	case codes.ResourceExhausted:
+		return ErrorClassRateLimited
+	case codes.Unavailable:
+		return ErrorClassUnavailable
+	case codes.Internal, codes.DataLoss, codes.Unimplemented:
+		return ErrorClassInternal
+	default:
+		return ErrorClassUnknown
+	}
+}
+
+func classifyHTTPStatus(code int) string {
+	switch {
+	case code == http.StatusNotFound:
+		return ErrorClassNotFound
+	case code == http.StatusTooManyRequests:
+		return ErrorClassRateLimited
+	case code == http.StatusRequestTimeout || code == http.StatusGatewayTimeout:
+		return ErrorClassTimeout
+	case code == http.StatusServiceUnavailable || code == http.StatusBadGateway:
+		return ErrorClassUnavailable
+	case code >= http.StatusInternalServerError:
+		return ErrorClassInternal
+	case code >= http.StatusBadRequest:
+		return ErrorClassInvalidArgument
+	default:
+		return ErrorClassUnknown
+	}
+}
@@ -0,0 +1,104 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2023 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package telemetrytest provides in-memory exporters to inspect the spans and metrics
+// emitted by the instrumented access client in tests.
+package telemetrytest
+
+import (
+	"context"
+
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/onflow/flow-go-sdk/access/telemetry"
+)
+
+// Recorder keeps every span and metric emitted through its providers in memory.
+type Recorder struct {
+	spans          *tracetest.InMemoryExporter
+	reader         sdkmetric.Reader
+	TracerProvider *sdktrace.TracerProvider
+	MeterProvider  *sdkmetric.MeterProvider
+}
+
+// NewRecorder creates a recorder with in-memory tracer and meter providers.
+func NewRecorder() *Recorder {
+	spans := tracetest.NewInMemoryExporter()
+	reader := sdkmetric.NewManualReader()
+
+	return &Recorder{
+		spans:          spans,
+		reader:         reader,
+		TracerProvider: sdktrace.NewTracerProvider(sdktrace.WithSyncer(spans)),
+		MeterProvider:  sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)),
+	}
+}
+
+// Options returns the telemetry options that route the instrumentation to the recorder.
+func (r *Recorder) Options() []telemetry.Option {
+	return []telemetry.Option{
+		telemetry.WithTracerProvider(r.TracerProvider),
+		telemetry.WithMeterProvider(r.MeterProvider),
+	}
+}
+
+// Spans returns the spans ended so far.
+func (r *Recorder) Spans() tracetest.SpanStubs {
+	return r.spans.GetSpans()
+}
+
+// Metrics collects the metrics recorded so far.
+func (r *Recorder) Metrics(ctx context.Context) (metricdata.ResourceMetrics, error) {
+	var rm metricdata.ResourceMetrics
+	err := r.reader.Collect(ctx, &rm)
+	return rm, err
+}
+
+// Metric returns the metric with the provided name, or false if it was not recorded.
+func (r *Recorder) Metric(ctx context.Context, name string) (metricdata.Metrics, bool, error) {
+	rm, err := r.Metrics(ctx)
+	if err != nil {
+		return metricdata.Metrics{}, false, err
+	}
+
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name == name {
+				return m, true, nil
+			}
+		}
+	}
+
+	return metricdata.Metrics{}, false, nil
+}
+
+// Reset removes the spans recorded so far.
+func (r *Recorder) Reset() {
+	r.spans.Reset()
+}
+
+// Shutdown shuts the tracer and meter providers down.
+func (r *Recorder) Shutdown(ctx context.Context) error {
+	if err := r.TracerProvider.Shutdown(ctx); err != nil {
+		return err
+	}
+	return r.MeterProvider.Shutdown(ctx)
+}
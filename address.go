@@ -19,10 +19,13 @@
 package flow
 
 import (
+	"database/sql/driver"
 	"encoding/binary"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"strings"
+	"sync"
 )
 
 // Address represents the 8 byte address of an account.
@@ -41,6 +44,11 @@ type AddressGenerator struct {
 //
 // Each addressing state is mapped to exactly one address, meaning there are as
 // many addresses as states. State values are incremented from 0 to 2^k-1.
+//
+// The chain ID is not checked: generating an address panics for chains without a
+// registered address scheme.
+//
+// Deprecated: use NewChainAddressGenerator, which returns ErrUnknownChain instead of panicking.
 func NewAddressGenerator(chainID ChainID) *AddressGenerator {
 	return &AddressGenerator{
 		chainID: chainID,
@@ -48,6 +56,17 @@ func NewAddressGenerator(chainID ChainID) *AddressGenerator {
 	}
 }
 
+// NewChainAddressGenerator creates a new address generator for the given chain ID,
+// starting from the zero address state.
+//
+// ErrUnknownChain is returned if no address scheme is registered for the chain ID.
+func NewChainAddressGenerator(chainID ChainID) (*AddressGenerator, error) {
+	if _, err := chainID.AddressScheme(); err != nil {
+		return nil, err
+	}
+	return newAddressGeneratorAtState(chainID, zeroAddressState), nil
+}
+
 func newAddressGeneratorAtState(chainID ChainID, state addressState) *AddressGenerator {
 	return &AddressGenerator{
 		chainID: chainID,
@@ -100,37 +119,123 @@ const (
 	serviceAddressState = addressState(1)
 )
 
-// chainCustomizer derives the constant used to generate addresses for
-// the given chain.
-func chainCustomizer(chain ChainID) uint64 {
-	switch chain {
-	case Mainnet:
-		return 0
-	case Testnet:
-		return invalidCodeTestNetwork
-	case Sandboxnet:
-		return invalidCodeSandboxNetwork
-	case Emulator, Localnet, Benchnet, BftTestnet:
-		return invalidCodeTransientNetwork
-	default:
-		panic(fmt.Sprintf("chain ID [%s] is invalid or does not support linear code address generation", chain))
+// ErrUnknownChain is returned when no address scheme is registered for a chain ID.
+var ErrUnknownChain = errors.New("unknown chain ID")
+
+// An AddressScheme describes how the account addresses of a chain are derived from the
+// addressing states.
+type AddressScheme struct {
+	// Monotonic is true if the addresses are the addressing states themselves, as generated
+	// by the emulator in monotonic mode, instead of customized linear code words.
+	Monotonic bool
+	// Customizer is XORed with the linear code words to derive the addresses of the chain.
+	// It must not be a code word, so that the addresses of the chain are not valid mainnet
+	// addresses. It is ignored for monotonic schemes.
+	Customizer uint64
+}
+
+var (
+	addressSchemesMu sync.RWMutex
+	addressSchemes   = map[ChainID]AddressScheme{
+		Mainnet:           {Customizer: 0},
+		Testnet:           {Customizer: invalidCodeTestNetwork},
+		Sandboxnet:        {Customizer: invalidCodeSandboxNetwork},
+		Emulator:          {Customizer: invalidCodeTransientNetwork},
+		Localnet:          {Customizer: invalidCodeTransientNetwork},
+		Benchnet:          {Customizer: invalidCodeTransientNetwork},
+		BftTestnet:        {Customizer: invalidCodeTransientNetwork},
+		MonotonicEmulator: {Monotonic: true},
+	}
+	// customLinearCodeChains are the registered chains using linear code address generation,
+	// in registration order.
+	customLinearCodeChains []ChainID
+)
+
+// RegisterAddressScheme registers the address scheme of a custom chain ID, so that
+// addresses can be generated and validated for it.
+//
+// An error is returned if a scheme is already registered for the chain ID, or if the
+// customizer of a linear code scheme is a code word.
+func RegisterAddressScheme(chain ChainID, scheme AddressScheme) error {
+	if chain == "" {
+		return fmt.Errorf("chain ID must not be empty")
+	}
+	if scheme.Monotonic {
+		scheme.Customizer = 0
+	} else if parity(scheme.Customizer) == 0 {
+		return fmt.Errorf("customizer %016x for chain %s must not be a code word", scheme.Customizer, chain)
+	}
+
+	addressSchemesMu.Lock()
+	defer addressSchemesMu.Unlock()
+
+	if _, ok := addressSchemes[chain]; ok {
+		return fmt.Errorf("an address scheme is already registered for chain %s", chain)
+	}
+
+	addressSchemes[chain] = scheme
+	if !scheme.Monotonic {
+		customLinearCodeChains = append(customLinearCodeChains, chain)
+	}
+	return nil
+}
+
+// unregisterAddressScheme removes the address scheme of a custom chain ID, so that tests
+// registering chains can restore the registry.
+func unregisterAddressScheme(chain ChainID) {
+	addressSchemesMu.Lock()
+	defer addressSchemesMu.Unlock()
+
+	delete(addressSchemes, chain)
+	for i, c := range customLinearCodeChains {
+		if c == chain {
+			customLinearCodeChains = append(customLinearCodeChains[:i:i], customLinearCodeChains[i+1:]...)
+			break
+		}
+	}
+}
+
+// AddressScheme returns the address scheme of the chain.
+//
+// ErrUnknownChain is returned if no scheme is registered for the chain ID.
+func (id ChainID) AddressScheme() (AddressScheme, error) {
+	addressSchemesMu.RLock()
+	defer addressSchemesMu.RUnlock()
+
+	scheme, ok := addressSchemes[id]
+	if !ok {
+		return AddressScheme{}, fmt.Errorf("%w: %s", ErrUnknownChain, id)
 	}
+	return scheme, nil
 }
 
 // EmptyAddress is the empty address (0x0000000000000000).
 var EmptyAddress = Address{}
 
 // ServiceAddress is the first generated account address.
+//
+// ServiceAddress panics for chains without a registered address scheme.
+//
+// Deprecated: use ServiceAddressForChain, which returns ErrUnknownChain instead of panicking.
 func ServiceAddress(chain ChainID) Address {
 	return generateAddress(chain, serviceAddressState)
 }
 
+// ServiceAddressForChain returns the first generated account address of the chain.
+//
+// ErrUnknownChain is returned if no address scheme is registered for the chain ID.
+func ServiceAddressForChain(chain ChainID) (Address, error) {
+	return AddressAtIndex(chain, uint64(serviceAddressState))
+}
+
 // zeroAddress represents the "zero address" (account that no one owns).
 func zeroAddress(chain ChainID) Address {
 	return generateAddress(chain, zeroAddressState)
 }
 
 // HexToAddress converts a hex string to an Address.
+//
+// Invalid hex is decoded as the empty address, use ParseAddress to check the input.
 func HexToAddress(h string) Address {
 	trimmed := strings.TrimPrefix(h, "0x")
 	if len(trimmed)%2 == 1 {
@@ -166,15 +271,114 @@ func (a Address) String() string {
 	return a.Hex()
 }
 
+// ParseAddress parses the hex representation of an address, with an optional 0x prefix.
+//
+// Unlike HexToAddress, an error is returned if the input is not valid hex or does not
+// have exactly 16 hex digits.
+func ParseAddress(h string) (Address, error) {
+	trimmed := strings.TrimPrefix(h, "0x")
+	if len(trimmed) != 2*AddressLength {
+		return EmptyAddress, fmt.Errorf("invalid address %q: expected %d hex digits, got %d", h, 2*AddressLength, len(trimmed))
+	}
+	return parseAddressHex(h, trimmed)
+}
+
+// ParseAddressLenient parses the hex representation of an address, with an optional 0x prefix.
+//
+// Addresses with fewer than 16 hex digits, such as "0x1", are padded with zeros on the left.
+// An error is returned if the input is empty, is not valid hex or has more than 16 hex digits.
+func ParseAddressLenient(h string) (Address, error) {
+	trimmed := strings.TrimPrefix(h, "0x")
+	if len(trimmed) == 0 || len(trimmed) > 2*AddressLength {
+		return EmptyAddress, fmt.Errorf("invalid address %q: expected 1 to %d hex digits, got %d", h, 2*AddressLength, len(trimmed))
+	}
+	if len(trimmed)%2 == 1 {
+		trimmed = "0" + trimmed
+	}
+	return parseAddressHex(h, trimmed)
+}
+
+// ParseChainAddress parses the hex representation of an address as ParseAddress does, and
+// returns an InvalidAddressError if the address is not a valid account address for the chain.
+//
+// ErrUnknownChain is returned if no address scheme is registered for the chain ID.
+func ParseChainAddress(h string, chain ChainID) (Address, error) {
+	scheme, err := chain.AddressScheme()
+	if err != nil {
+		return EmptyAddress, err
+	}
+	a, err := ParseAddress(h)
+	if err != nil {
+		return EmptyAddress, err
+	}
+	if !scheme.isValid(a) {
+		return EmptyAddress, InvalidAddressError{Address: a, Chain: chain}
+	}
+	return a, nil
+}
+
+func parseAddressHex(h, trimmed string) (Address, error) {
+	b, err := hex.DecodeString(trimmed)
+	if err != nil {
+		return EmptyAddress, fmt.Errorf("invalid address %q: %w", h, err)
+	}
+	return BytesToAddress(b), nil
+}
+
 func (a Address) MarshalJSON() ([]byte, error) {
 	return []byte(fmt.Sprintf("\"%s\"", a.Hex())), nil
 }
 
+// UnmarshalJSON decodes a JSON string as UnmarshalText does. A JSON null is ignored.
 func (a *Address) UnmarshalJSON(data []byte) error {
-	*a = HexToAddress(strings.Trim(string(data), "\""))
+	return unmarshalJSONText(data, a)
+}
+
+// MarshalText encodes the address as hex, without prefix.
+func (a Address) MarshalText() ([]byte, error) {
+	return []byte(a.Hex()), nil
+}
+
+// UnmarshalText decodes the address as ParseAddressLenient does, an empty text being the empty address.
+func (a *Address) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*a = EmptyAddress
+		return nil
+	}
+
+	parsed, err := ParseAddressLenient(string(text))
+	if err != nil {
+		return err
+	}
+	*a = parsed
 	return nil
 }
 
+// Value implements driver.Valuer, the address is stored as its 8 bytes.
+func (a Address) Value() (driver.Value, error) {
+	return a.Bytes(), nil
+}
+
+// Scan implements sql.Scanner. It accepts the 8 bytes of the address, or its hex
+// representation as a string or bytes, and stores the empty address for NULL.
+func (a *Address) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*a = EmptyAddress
+		return nil
+	case []byte:
+		if len(v) == AddressLength {
+			*a = BytesToAddress(v)
+			return nil
+		}
+		return a.UnmarshalText(v)
+	case string:
+		return a.UnmarshalText([]byte(v))
+	default:
+		return fmt.Errorf("cannot scan %T into an address", src)
+	}
+}
+
 const (
 	// [n,k,d]-Linear code parameters
 	// The linear code used in the account addressing is a [64,45,7]
@@ -218,7 +422,21 @@ func (a *Address) uint64() uint64 {
 // (network) specifies the network to generate the address for (Flow Mainnet, testent..)
 // The function assumes the state is valid (<2^k) which means
 // a check on the state should be done before calling this function.
+//
+// The function panics if the chain has no registered address scheme.
 func generateAddress(chain ChainID, state addressState) Address {
+	scheme, err := chain.AddressScheme()
+	if err != nil {
+		panic(fmt.Sprintf("chain ID [%s] is invalid or has no registered address scheme", chain))
+	}
+	return scheme.generate(state)
+}
+
+func (s AddressScheme) generate(state addressState) Address {
+	if s.Monotonic {
+		return uint64ToAddress(uint64(state))
+	}
+
 	index := uint64(state)
 
 	// Multiply the index GF(2) vector by the code generator matrix
@@ -231,7 +449,7 @@ func generateAddress(chain ChainID, state addressState) Address {
 	}
 
 	// customize the code word for a specific network
-	address ^= chainCustomizer(chain)
+	address ^= s.Customizer
 	return uint64ToAddress(address)
 }
 
@@ -241,23 +459,188 @@ func generateAddress(chain ChainID, state addressState) Address {
 // This is an off-chain check that only tells whether the address format is
 // valid. If the function returns true, this does not mean a Flow account with
 // this address has been generated. Such a test would require an on-chain check.
+//
+// False is returned for chains without a registered address scheme, use
+// ChainID.AddressScheme to tell an unknown chain apart from an invalid address.
 func (a *Address) IsValid(chain ChainID) bool {
-	codeWord := a.uint64()
-	codeWord ^= chainCustomizer(chain)
+	scheme, err := chain.AddressScheme()
+	if err != nil {
+		return false
+	}
+	return scheme.isValid(*a)
+}
+
+func (s AddressScheme) isValid(a Address) bool {
+	if s.Monotonic {
+		v := a.uint64()
+		return v > 0 && v <= maxState
+	}
 
+	codeWord := a.uint64() ^ s.Customizer
 	if codeWord == 0 {
 		return false
 	}
+	return parity(codeWord) == 0
+}
 
-	// Multiply the code word GF(2)-vector by the parity-check matrix
-	parity := uint(0)
+// parity multiplies the code word GF(2)-vector by the parity-check matrix,
+// the result is zero for code words.
+func parity(codeWord uint64) uint {
+	p := uint(0)
 	for i := 0; i < linearCodeN; i++ {
 		if codeWord&1 == 1 {
-			parity ^= parityCheckMatrixColumns[i]
+			p ^= parityCheckMatrixColumns[i]
 		}
 		codeWord >>= 1
 	}
-	return parity == 0
+	return p
+}
+
+// linearCodeChains are the chains using linear code address generation, in the order they are
+// reported by Address.Chains. The transient networks share the same addresses.
+var linearCodeChains = []ChainID{
+	Mainnet,
+	Testnet,
+	Sandboxnet,
+	Emulator,
+	Localnet,
+	Benchnet,
+	BftTestnet,
+}
+
+// Chains returns the chains the address is a valid account address for.
+//
+// The transient networks (Emulator, Localnet, Benchnet and BftTestnet) share the same
+// addresses, so either none or all of them are returned. Registered linear code chains
+// follow the built-in ones, and chains with monotonic addresses are never returned, as
+// most small values are valid monotonic addresses.
+func (a Address) Chains() []ChainID {
+	addressSchemesMu.RLock()
+	candidates := make([]ChainID, 0, len(linearCodeChains)+len(customLinearCodeChains))
+	candidates = append(candidates, linearCodeChains...)
+	candidates = append(candidates, customLinearCodeChains...)
+	addressSchemesMu.RUnlock()
+
+	var chains []ChainID
+	for _, chain := range candidates {
+		if a.IsValid(chain) {
+			chains = append(chains, chain)
+		}
+	}
+	return chains
+}
+
+// Chain returns the chain the address is a valid account address for.
+//
+// Emulator is returned for addresses of the transient networks. False is returned
+// if the address is not valid for any chain.
+func (a Address) Chain() (ChainID, bool) {
+	chains := a.Chains()
+	if len(chains) == 0 {
+		return "", false
+	}
+	return chains[0], true
+}
+
+// Index returns the addressing state the address was generated from on the given chain,
+// which is the creation order of the account: the service account has index 1.
+//
+// An error is returned if the address is not a valid account address for the chain, or if
+// the chain has no registered address scheme.
+func (a Address) Index(chain ChainID) (uint64, error) {
+	scheme, err := chain.AddressScheme()
+	if err != nil {
+		return 0, err
+	}
+	if !scheme.isValid(a) {
+		return 0, fmt.Errorf("address %s is not valid for chain %s", a, chain)
+	}
+	if scheme.Monotonic {
+		return a.uint64(), nil
+	}
+
+	codeWord := a.uint64() ^ scheme.Customizer
+
+	// apply the reduced rows of the generator matrix to recover the index bits
+	index := uint64(0)
+	for _, row := range generatorMatrixReduced {
+		if codeWord&(1<<row.pivot) != 0 {
+			codeWord ^= row.vector
+			index ^= row.index
+		}
+	}
+
+	return index, nil
+}
+
+// AddressAtIndex returns the account address generated from the given addressing state on the given chain.
+//
+// An error is returned if the index is larger than the maximum addressing state, 2^45-1,
+// or if the chain has no registered address scheme.
+func AddressAtIndex(chain ChainID, index uint64) (Address, error) {
+	scheme, err := chain.AddressScheme()
+	if err != nil {
+		return EmptyAddress, err
+	}
+	if index > maxState {
+		return EmptyAddress, fmt.Errorf("index %d is larger than the maximum index %d", index, uint64(maxState))
+	}
+	return scheme.generate(addressState(index)), nil
+}
+
+// reducedRow is a row of the generator matrix in reduced row echelon form.
+type reducedRow struct {
+	// pivot is the bit of the row which is not set in any other row.
+	pivot uint
+	// vector is the row.
+	vector uint64
+	// index is the set of generator matrix rows combined into the row.
+	index uint64
+}
+
+// generatorMatrixReduced is the generator matrix in reduced row echelon form,
+// used to recover the addressing state from an address.
+var generatorMatrixReduced = reduceGeneratorMatrix()
+
+// reduceGeneratorMatrix performs a Gaussian elimination of the generator matrix over GF(2).
+func reduceGeneratorMatrix() []reducedRow {
+	rows := make([]reducedRow, linearCodeK)
+	for i, vector := range generatorMatrixRows {
+		rows[i] = reducedRow{vector: vector, index: 1 << i}
+	}
+
+	next := 0
+	for bit := 0; bit < linearCodeN && next < len(rows); bit++ {
+		mask := uint64(1) << bit
+
+		pivot := -1
+		for i := next; i < len(rows); i++ {
+			if rows[i].vector&mask != 0 {
+				pivot = i
+				break
+			}
+		}
+		if pivot < 0 {
+			continue
+		}
+
+		rows[next], rows[pivot] = rows[pivot], rows[next]
+		rows[next].pivot = uint(bit)
+
+		for i := range rows {
+			if i != next && rows[i].vector&mask != 0 {
+				rows[i].vector ^= rows[next].vector
+				rows[i].index ^= rows[next].index
+			}
+		}
+		next++
+	}
+
+	if next != linearCodeK {
+		panic("the address generator matrix is not of full rank")
+	}
+
+	return rows
 }
 
 // invalid code-words in the [64,45] code
@@ -79,9 +79,11 @@ func TestAddressConstants(t *testing.T) {
 
 	for _, net := range networks {
 		// check the zero and service constants
-		expected := uint64ToAddress(chainCustomizer(net))
+		scheme, err := net.AddressScheme()
+		require.NoError(t, err)
+		expected := uint64ToAddress(scheme.Customizer)
 		assert.Equal(t, zeroAddress(net), expected)
-		expected = uint64ToAddress(generatorMatrixRows[0] ^ chainCustomizer(net))
+		expected = uint64ToAddress(generatorMatrixRows[0] ^ scheme.Customizer)
 		assert.Equal(t, ServiceAddress(net), expected)
 
 		// check the transition from account zero to service
@@ -301,3 +303,239 @@ func TestAddressesIntersection(t *testing.T) {
 		}
 	}
 }
+
+func TestAddressChains(t *testing.T) {
+	for _, chain := range []ChainID{Mainnet, Testnet, Sandboxnet, Emulator} {
+		address := NewAddressGenerator(chain).NextAddress()
+
+		detected, ok := address.Chain()
+		require.True(t, ok)
+		assert.Equal(t, chain, detected)
+	}
+
+	assert.Equal(t,
+		[]ChainID{Emulator, Localnet, Benchnet, BftTestnet},
+		ServiceAddress(Localnet).Chains(),
+	)
+
+	_, ok := HexToAddress("01").Chain()
+	assert.False(t, ok)
+	assert.Empty(t, EmptyAddress.Chains())
+}
+
+func TestAddressIndex(t *testing.T) {
+	for _, chain := range []ChainID{Mainnet, Testnet, Sandboxnet, Emulator} {
+		gen := NewAddressGenerator(chain)
+		for i := uint64(1); i <= 100; i++ {
+			index, err := gen.NextAddress().Index(chain)
+			require.NoError(t, err)
+			assert.Equal(t, i, index)
+		}
+
+		for _, i := range []uint64{1 << 20, 123456789, maxState} {
+			address, err := AddressAtIndex(chain, i)
+			require.NoError(t, err)
+			assert.Equal(t, gen.SetIndex(uint(i)).Address(), address)
+
+			index, err := address.Index(chain)
+			require.NoError(t, err)
+			assert.Equal(t, i, index)
+		}
+	}
+
+	index, err := ServiceAddress(Mainnet).Index(Mainnet)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), index)
+
+	_, err = ServiceAddress(Mainnet).Index(Testnet)
+	assert.Error(t, err)
+
+	_, err = AddressAtIndex(Mainnet, maxState+1)
+	assert.Error(t, err)
+}
+
+func TestAddressMonotonic(t *testing.T) {
+	gen, err := NewChainAddressGenerator(MonotonicEmulator)
+	require.NoError(t, err)
+
+	assert.Equal(t, HexToAddress("01"), gen.NextAddress())
+	assert.Equal(t, HexToAddress("02"), gen.NextAddress())
+	assert.Equal(t, HexToAddress("01"), ServiceAddress(MonotonicEmulator))
+
+	address := HexToAddress("0a")
+	assert.True(t, address.IsValid(MonotonicEmulator))
+	assert.False(t, EmptyAddress.IsValid(MonotonicEmulator))
+
+	index, err := address.Index(MonotonicEmulator)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(10), index)
+
+	atIndex, err := AddressAtIndex(MonotonicEmulator, 10)
+	require.NoError(t, err)
+	assert.Equal(t, address, atIndex)
+
+	// monotonic chains are not detected
+	assert.Empty(t, address.Chains())
+}
+
+func TestRegisterAddressScheme(t *testing.T) {
+	t.Run("linear code", func(t *testing.T) {
+		chain := ChainID("flow-test-custom-linear")
+		registerTestAddressScheme(t, chain, AddressScheme{Customizer: invalidCodeWord})
+
+		gen, err := NewChainAddressGenerator(chain)
+		require.NoError(t, err)
+
+		address := gen.NextAddress()
+		assert.Equal(t, uint64ToAddress(generatorMatrixRows[0]^invalidCodeWord), address)
+		assert.True(t, address.IsValid(chain))
+		assert.False(t, address.IsValid(Mainnet))
+		assert.Equal(t, []ChainID{chain}, address.Chains())
+
+		index, err := address.Index(chain)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(1), index)
+	})
+
+	t.Run("monotonic", func(t *testing.T) {
+		chain := ChainID("flow-test-custom-monotonic")
+		registerTestAddressScheme(t, chain, AddressScheme{Monotonic: true})
+		assert.Equal(t, HexToAddress("01"), ServiceAddress(chain))
+	})
+
+	t.Run("already registered", func(t *testing.T) {
+		assert.Error(t, RegisterAddressScheme(Mainnet, AddressScheme{Customizer: invalidCodeWord}))
+	})
+
+	t.Run("code word customizer", func(t *testing.T) {
+		chain := ChainID("flow-test-custom-code-word")
+		assert.Error(t, RegisterAddressScheme(chain, AddressScheme{Customizer: 0}))
+		assert.Error(t, RegisterAddressScheme(chain, AddressScheme{Customizer: generatorMatrixRows[0]}))
+
+		_, err := chain.AddressScheme()
+		assert.ErrorIs(t, err, ErrUnknownChain)
+	})
+}
+
+// registerTestAddressScheme registers a chain for the duration of the test.
+func registerTestAddressScheme(t *testing.T, chain ChainID, scheme AddressScheme) {
+	require.NoError(t, RegisterAddressScheme(chain, scheme))
+	t.Cleanup(func() { unregisterAddressScheme(chain) })
+}
+
+func TestAddressUnknownChain(t *testing.T) {
+	chain := ChainID("flow-unknown")
+
+	_, err := NewChainAddressGenerator(chain)
+	assert.ErrorIs(t, err, ErrUnknownChain)
+
+	_, err = ServiceAddressForChain(chain)
+	assert.ErrorIs(t, err, ErrUnknownChain)
+
+	_, err = AddressAtIndex(chain, 1)
+	assert.ErrorIs(t, err, ErrUnknownChain)
+
+	address, err := ServiceAddressForChain(Mainnet)
+	require.NoError(t, err)
+	assert.Equal(t, ServiceAddress(Mainnet), address)
+
+	_, err = address.Index(chain)
+	assert.ErrorIs(t, err, ErrUnknownChain)
+
+	_, err = ParseChainAddress(address.Hex(), chain)
+	assert.ErrorIs(t, err, ErrUnknownChain)
+
+	_, err = chain.AddressScheme()
+	assert.ErrorIs(t, err, ErrUnknownChain)
+
+	tx := NewTransaction().SetPayer(address)
+	assert.ErrorIs(t, tx.Validate(chain), ErrUnknownChain)
+
+	// the deprecated functions without error result keep panicking for compatibility
+	assert.False(t, address.IsValid(chain))
+	assert.Panics(t, func() { NewAddressGenerator(chain).NextAddress() })
+	assert.Panics(t, func() { ServiceAddress(chain) })
+}
+
+func TestParseAddress(t *testing.T) {
+	service := ServiceAddress(Mainnet)
+
+	for _, h := range []string{service.Hex(), "0x" + service.Hex()} {
+		address, err := ParseAddress(h)
+		require.NoError(t, err)
+		assert.Equal(t, service, address)
+	}
+
+	for _, h := range []string{"", "0x", "01", "0x" + service.Hex() + "00", "zz" + service.Hex()[2:]} {
+		_, err := ParseAddress(h)
+		assert.Error(t, err, h)
+	}
+
+	t.Run("lenient", func(t *testing.T) {
+		for _, h := range []string{"1", "01", "0x1", "0x0000000000000001"} {
+			address, err := ParseAddressLenient(h)
+			require.NoError(t, err, h)
+			assert.Equal(t, HexToAddress("01"), address)
+		}
+
+		for _, h := range []string{"", "0x", "0xzz", "00000000000000001"} {
+			_, err := ParseAddressLenient(h)
+			assert.Error(t, err, h)
+		}
+	})
+
+	t.Run("chain", func(t *testing.T) {
+		address, err := ParseChainAddress(service.Hex(), Mainnet)
+		require.NoError(t, err)
+		assert.Equal(t, service, address)
+
+		_, err = ParseChainAddress(service.Hex(), Testnet)
+		assert.Equal(t, InvalidAddressError{Address: service, Chain: Testnet}, err)
+	})
+}
+
+func TestAddressEncodings(t *testing.T) {
+	addr := ServiceAddress(Mainnet)
+
+	t.Run("JSON", func(t *testing.T) {
+		var out addressWrapper
+		require.NoError(t, json.Unmarshal([]byte(`{"Address":"0x01"}`), &out))
+		assert.Equal(t, HexToAddress("01"), out.Address)
+
+		assert.Error(t, json.Unmarshal([]byte(`{"Address":"0xzz"}`), &out))
+		assert.Error(t, json.Unmarshal([]byte(`{"Address":"0x"}`), &out))
+		assert.Error(t, json.Unmarshal([]byte(`{"Address":1}`), &out))
+
+		// an empty string is the empty address
+		require.NoError(t, json.Unmarshal([]byte(`{"Address":""}`), &out))
+		assert.Equal(t, EmptyAddress, out.Address)
+
+		text := addr
+		require.NoError(t, text.UnmarshalText(nil))
+		assert.Equal(t, EmptyAddress, text)
+
+		// map keys are encoded as text
+		data, err := json.Marshal(map[Address]int{addr: 1})
+		require.NoError(t, err)
+		assert.Equal(t, `{"`+addr.Hex()+`":1}`, string(data))
+	})
+
+	t.Run("SQL", func(t *testing.T) {
+		value, err := addr.Value()
+		require.NoError(t, err)
+		assert.Equal(t, addr.Bytes(), value)
+
+		for _, src := range []any{value, addr.Hex(), []byte("0x" + addr.Hex())} {
+			var scanned Address
+			require.NoError(t, scanned.Scan(src))
+			assert.Equal(t, addr, scanned)
+		}
+
+		scanned := addr
+		require.NoError(t, scanned.Scan(nil))
+		assert.Equal(t, EmptyAddress, scanned)
+
+		assert.Error(t, scanned.Scan(42))
+		assert.Error(t, scanned.Scan("0xzz"))
+	})
+}
@@ -18,7 +18,12 @@
 
 package flow
 
-import "time"
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
 
 // Block is a set of state mutations applied to the Flow blockchain.
 type Block struct {
@@ -33,6 +38,26 @@ type BlockHeader struct {
 	Height    uint64
 	Timestamp time.Time
 	Status    BlockStatus
+
+	// PayloadHash is the hash of the block payload.
+	PayloadHash Identifier
+	// View is the consensus view in which the block was proposed.
+	View uint64
+	// ParentView is the consensus view of the parent block.
+	ParentView uint64
+	// ParentVoterIndices is the bit vector of the consensus nodes which voted for the parent block.
+	ParentVoterIndices []byte
+	// ParentVoterIDs are the IDs of the consensus nodes which voted for the parent block,
+	// only set by nodes predating ParentVoterIndices.
+	ParentVoterIDs []Identifier
+	// ParentVoterSigData is the aggregated signature of the votes for the parent block.
+	ParentVoterSigData []byte
+	// ProposerID is the ID of the consensus node which proposed the block.
+	ProposerID Identifier
+	// ProposerSigData is the signature of the proposer over the block.
+	ProposerSigData []byte
+	// ChainID is the ID of the chain the block belongs to.
+	ChainID ChainID
 }
 
 // BlockStatus represents the status of a block.
@@ -58,6 +83,93 @@ func BlockStatusFromString(s string) BlockStatus {
 	}
 }
 
+var blockStatusNames = [...]string{"BLOCK_UNKNOWN", "BLOCK_FINALIZED", "BLOCK_SEALED"}
+
+// String returns the string representation of a block status.
+func (s BlockStatus) String() string {
+	if s < 0 || int(s) >= len(blockStatusNames) {
+		return fmt.Sprintf("BlockStatus(%d)", int(s))
+	}
+	return blockStatusNames[s]
+}
+
+// ParseBlockStatus parses the string representation of a block status.
+//
+// Unlike BlockStatusFromString, an error is returned for unknown strings.
+func ParseBlockStatus(str string) (BlockStatus, error) {
+	for i, name := range blockStatusNames {
+		if name == str {
+			return BlockStatus(i), nil
+		}
+	}
+	return BlockStatusUnknown, fmt.Errorf("invalid block status %q", str)
+}
+
+// MarshalText encodes the block status as its string representation.
+func (s BlockStatus) MarshalText() ([]byte, error) {
+	if s < 0 || int(s) >= len(blockStatusNames) {
+		return nil, fmt.Errorf("invalid block status %d", int(s))
+	}
+	return []byte(blockStatusNames[s]), nil
+}
+
+// UnmarshalText decodes the block status as ParseBlockStatus does.
+func (s *BlockStatus) UnmarshalText(text []byte) error {
+	status, err := ParseBlockStatus(string(text))
+	if err != nil {
+		return err
+	}
+	*s = status
+	return nil
+}
+
+// MarshalJSON encodes the block status as a JSON string.
+func (s BlockStatus) MarshalJSON() ([]byte, error) {
+	return marshalJSONText(s)
+}
+
+// UnmarshalJSON decodes a JSON string, or the JSON number of the status. A JSON null is ignored.
+func (s *BlockStatus) UnmarshalJSON(data []byte) error {
+	if string(data) != "null" {
+		var n int
+		if err := json.Unmarshal(data, &n); err == nil {
+			return s.Scan(int64(n))
+		}
+	}
+	return unmarshalJSONText(data, s)
+}
+
+// Value implements driver.Valuer, the block status is stored as its string representation.
+func (s BlockStatus) Value() (driver.Value, error) {
+	text, err := s.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return string(text), nil
+}
+
+// Scan implements sql.Scanner. It accepts the string representation of the status or
+// its integer value, and stores BlockStatusUnknown for NULL.
+func (s *BlockStatus) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*s = BlockStatusUnknown
+		return nil
+	case int64:
+		if v < 0 || v >= int64(len(blockStatusNames)) {
+			return fmt.Errorf("invalid block status %d", v)
+		}
+		*s = BlockStatus(v)
+		return nil
+	case []byte:
+		return s.UnmarshalText(v)
+	case string:
+		return s.UnmarshalText([]byte(v))
+	default:
+		return fmt.Errorf("cannot scan %T into a block status", src)
+	}
+}
+
 // BlockPayload is the full contents of a block.
 //
 // A payload contains the collection guarantees and seals for a block.
@@ -75,4 +187,27 @@ type BlockSeal struct {
 	// The ID of the execution receipt generated by the Verifier nodes; the work of verifying a
 	// block produces the same receipt among all verifying nodes
 	ExecutionReceiptID Identifier
+
+	// The ID of the execution result sealed by this seal.
+	ResultID Identifier
+
+	// The state commitment at the end of the sealed block.
+	FinalState StateCommitment
+
+	// ExecutionReceiptSignatures are the signatures of the execution receipts, only set by older access nodes.
+	ExecutionReceiptSignatures [][]byte
+
+	// ResultApprovalSignatures are the signatures of the result approvals, only set by older access nodes.
+	ResultApprovalSignatures [][]byte
+
+	// AggregatedApprovalSigs contains, for every chunk of the sealed result, the signatures of the
+	// verification nodes which approved it.
+	AggregatedApprovalSigs []*AggregatedSignature
+}
+
+// AggregatedSignature is the set of result approval signatures of a chunk, together with the
+// IDs of the verification nodes which produced them.
+type AggregatedSignature struct {
+	VerifierSignatures [][]byte
+	SignerIDs          []Identifier
 }
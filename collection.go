@@ -23,6 +23,11 @@ type Collection struct {
 	TransactionIDs []Identifier
 }
 
+// ID returns the canonical SHA3-256 hash of this collection.
+func (c Collection) ID() Identifier {
+	return HashToID(hashSHA3(c.Encode()))
+}
+
 // Encode returns the canonical RLP byte representation of this collection.
 func (c Collection) Encode() []byte {
 	transactionIDs := make([][]byte, len(c.TransactionIDs))
@@ -41,4 +46,19 @@ func (c Collection) Encode() []byte {
 // A CollectionGuarantee is an attestation signed by the nodes that have guaranteed a collection.
 type CollectionGuarantee struct {
 	CollectionID Identifier
+
+	// ReferenceBlockID is the ID of the block the collection references for its expiry.
+	ReferenceBlockID Identifier
+
+	// Signature is the aggregated signature of the guarantors.
+	Signature []byte
+
+	// SignerIndices is the bit vector of the collection nodes which guaranteed the collection.
+	SignerIndices []byte
+
+	// SignerIDs are the IDs of the guarantors, only set by older access nodes.
+	SignerIDs []Identifier
+
+	// Signatures are the individual signatures of the guarantors, only set by older access nodes.
+	Signatures [][]byte
 }
@@ -18,16 +18,66 @@
 
 package flow
 
+import (
+	"errors"
+	"fmt"
+)
+
 type ExecutionResult struct {
 	PreviousResultID Identifier // commit of the previous ER
 	BlockID          Identifier // commit of the current block
 	Chunks           []*Chunk
 	ServiceEvents    []*ServiceEvent
+	ExecutionDataID  Identifier // ID of the execution data, not exposed by the REST API
+}
+
+// ErrServiceEventsNotHashable is returned when computing the ID of an execution result
+// carrying service events, which are hashed in their protocol representation.
+var ErrServiceEventsNotHashable = errors.New("execution results with service events cannot be hashed locally")
+
+// ErrMissingExecutionDataID is returned when computing the ID of an execution result without
+// its execution data ID, such as the results returned by the REST API.
+var ErrMissingExecutionDataID = errors.New("execution results without execution data ID cannot be hashed")
+
+// ID returns the canonical SHA3-256 hash of this execution result.
+//
+// The ID can only be computed for results without service events, which is the case of all
+// blocks except the few ones ending an epoch phase, and with their execution data ID, which
+// is only returned by the gRPC API.
+func (r ExecutionResult) ID() (Identifier, error) {
+	if len(r.ServiceEvents) > 0 {
+		return EmptyID, ErrServiceEventsNotHashable
+	}
+	if r.ExecutionDataID == EmptyID {
+		return EmptyID, ErrMissingExecutionDataID
+	}
+
+	chunks := make([]chunkCanonicalForm, len(r.Chunks))
+	for i, chunk := range r.Chunks {
+		chunks[i] = chunk.canonicalForm()
+	}
+
+	temp := struct {
+		PreviousResultID Identifier
+		BlockID          Identifier
+		Chunks           []chunkCanonicalForm
+		ServiceEvents    []struct{}
+		ExecutionDataID  Identifier
+	}{
+		PreviousResultID: r.PreviousResultID,
+		BlockID:          r.BlockID,
+		Chunks:           chunks,
+		ServiceEvents:    []struct{}{},
+		ExecutionDataID:  r.ExecutionDataID,
+	}
+
+	return makeID(&temp), nil
 }
 
 type Chunk struct {
 	CollectionIndex      uint
 	StartState           StateCommitment // start state when starting executing this chunk
+	EventCollection      Identifier      // hash of the events emitted by the chunk
 	BlockID              Identifier      // Block id of the execution result this chunk belongs to
 	TotalComputationUsed uint64          // total amount of computation used by running all txs in this chunk
 	NumberOfTransactions uint16          // number of transactions inside the collection
@@ -35,7 +85,213 @@ type Chunk struct {
 	EndState             StateCommitment // EndState inferred from next chunk or from the ER
 }
 
+// chunkBodyCanonicalForm is the part of a chunk its ID is computed from.
+type chunkBodyCanonicalForm struct {
+	CollectionIndex      uint
+	StartState           StateCommitment
+	EventCollection      Identifier
+	BlockID              Identifier
+	TotalComputationUsed uint64
+	NumberOfTransactions uint64
+}
+
+// chunkCanonicalForm is the form of a chunk hashed as part of an execution result.
+type chunkCanonicalForm struct {
+	ChunkBody chunkBodyCanonicalForm
+	Index     uint64
+	EndState  StateCommitment
+}
+
+func (c Chunk) canonicalForm() chunkCanonicalForm {
+	return chunkCanonicalForm{
+		ChunkBody: chunkBodyCanonicalForm{
+			CollectionIndex:      c.CollectionIndex,
+			StartState:           c.StartState,
+			EventCollection:      c.EventCollection,
+			BlockID:              c.BlockID,
+			TotalComputationUsed: c.TotalComputationUsed,
+			NumberOfTransactions: uint64(c.NumberOfTransactions),
+		},
+		Index:    c.Index,
+		EndState: c.EndState,
+	}
+}
+
+// ID returns the canonical SHA3-256 hash of this chunk.
+//
+// As in the protocol, the index and end state of the chunk are not part of its ID.
+func (c Chunk) ID() Identifier {
+	body := c.canonicalForm().ChunkBody
+	return makeID(&body)
+}
+
 type ServiceEvent struct {
 	Type    string
 	Payload []byte
 }
+
+// DecodeServiceEvents decodes all the service events of the execution result, in order.
+//
+// See ServiceEvent.Decode for the types of the decoded events.
+func (r ExecutionResult) DecodeServiceEvents() ([]interface{}, error) {
+	events := make([]interface{}, len(r.ServiceEvents))
+	for i, e := range r.ServiceEvents {
+		event, err := e.Decode()
+		if err != nil {
+			return nil, err
+		}
+		events[i] = event
+	}
+	return events, nil
+}
+
+// ChunkAnomalyKind is the kind of inconsistency found in the chunks of an execution result.
+type ChunkAnomalyKind string
+
+const (
+	// ChunkAnomalyStateDiscontinuity indicates that the start state of a chunk differs from the end state of the previous chunk.
+	ChunkAnomalyStateDiscontinuity ChunkAnomalyKind = "state_discontinuity"
+	// ChunkAnomalyIndexMismatch indicates that the index of a chunk differs from its position in the result.
+	ChunkAnomalyIndexMismatch ChunkAnomalyKind = "index_mismatch"
+	// ChunkAnomalyBlockMismatch indicates that a chunk references another block than its execution result.
+	ChunkAnomalyBlockMismatch ChunkAnomalyKind = "block_mismatch"
+	// ChunkAnomalyCollectionMismatch indicates that a chunk does not match the collections of the block.
+	ChunkAnomalyCollectionMismatch ChunkAnomalyKind = "collection_mismatch"
+	// ChunkAnomalyTransactionCount indicates that the transaction count of a chunk differs from its collection.
+	ChunkAnomalyTransactionCount ChunkAnomalyKind = "transaction_count"
+)
+
+// A ChunkAnomaly is an inconsistency found in the chunks of an execution result.
+type ChunkAnomaly struct {
+	// ChunkIndex is the position of the chunk in the execution result.
+	ChunkIndex int
+	Kind       ChunkAnomalyKind
+	Message    string
+}
+
+// ChunkAnomalies checks the chunks of the execution result for inconsistencies.
+func (r ExecutionResult) ChunkAnomalies() []ChunkAnomaly {
+	var anomalies []ChunkAnomaly
+
+	for i, chunk := range r.Chunks {
+		if chunk.Index != uint64(i) {
+			anomalies = append(anomalies, ChunkAnomaly{
+				ChunkIndex: i,
+				Kind:       ChunkAnomalyIndexMismatch,
+				Message:    fmt.Sprintf("chunk at position %d has index %d", i, chunk.Index),
+			})
+		}
+
+		if chunk.BlockID != r.BlockID {
+			anomalies = append(anomalies, ChunkAnomaly{
+				ChunkIndex: i,
+				Kind:       ChunkAnomalyBlockMismatch,
+				Message:    fmt.Sprintf("chunk references block %s instead of %s", chunk.BlockID, r.BlockID),
+			})
+		}
+
+		if i > 0 && chunk.StartState != r.Chunks[i-1].EndState {
+			anomalies = append(anomalies, ChunkAnomaly{
+				ChunkIndex: i,
+				Kind:       ChunkAnomalyStateDiscontinuity,
+				Message: fmt.Sprintf(
+					"start state %s differs from the end state %s of the previous chunk",
+					Identifier(chunk.StartState),
+					Identifier(r.Chunks[i-1].EndState),
+				),
+			})
+		}
+	}
+
+	return anomalies
+}
+
+// ComputationSummary aggregates the computation of the chunks of an execution result.
+type ComputationSummary struct {
+	// TotalComputationUsed is the computation used by all the chunks.
+	TotalComputationUsed uint64
+	// TotalTransactions is the number of transactions executed by all the chunks.
+	TotalTransactions uint64
+	// ChunkComputationUsed is the computation used by each chunk, indexed by chunk.
+	ChunkComputationUsed []uint64
+	// MaxChunkIndex is the index of the chunk which used the most computation.
+	MaxChunkIndex int
+}
+
+// ComputationSummary aggregates the computation used by the chunks of the execution result.
+func (r ExecutionResult) ComputationSummary() ComputationSummary {
+	summary := ComputationSummary{
+		ChunkComputationUsed: make([]uint64, len(r.Chunks)),
+	}
+
+	for i, chunk := range r.Chunks {
+		summary.TotalComputationUsed += chunk.TotalComputationUsed
+		summary.TotalTransactions += uint64(chunk.NumberOfTransactions)
+		summary.ChunkComputationUsed[i] = chunk.TotalComputationUsed
+
+		if chunk.TotalComputationUsed > r.Chunks[summary.MaxChunkIndex].TotalComputationUsed {
+			summary.MaxChunkIndex = i
+		}
+	}
+
+	return summary
+}
+
+// ChunkCollection connects a chunk to the collection it executed.
+type ChunkCollection struct {
+	Chunk *Chunk
+	// CollectionID is the ID of the collection guaranteed in the block, empty for the system chunk.
+	CollectionID Identifier
+	// SystemChunk reports whether the chunk is the system chunk, executing the system transaction
+	// after all the collections of the block.
+	SystemChunk bool
+}
+
+// ChunkCollections maps each chunk of the execution result to the collection of the block it executed.
+//
+// The last chunk of a result executes the system transaction and has no collection. Chunks which
+// do not match a collection of the block are reported as ChunkAnomalyCollectionMismatch anomalies.
+func (r ExecutionResult) ChunkCollections(block Block) ([]ChunkCollection, []ChunkAnomaly) {
+	var anomalies []ChunkAnomaly
+	guarantees := block.CollectionGuarantees
+
+	if block.ID != EmptyID && block.ID != r.BlockID {
+		anomalies = append(anomalies, ChunkAnomaly{
+			ChunkIndex: -1,
+			Kind:       ChunkAnomalyBlockMismatch,
+			Message:    fmt.Sprintf("execution result of block %s mapped to block %s", r.BlockID, block.ID),
+		})
+	}
+
+	if len(r.Chunks) != len(guarantees)+1 {
+		anomalies = append(anomalies, ChunkAnomaly{
+			ChunkIndex: -1,
+			Kind:       ChunkAnomalyCollectionMismatch,
+			Message:    fmt.Sprintf("%d chunks for %d collections", len(r.Chunks), len(guarantees)),
+		})
+	}
+
+	collections := make([]ChunkCollection, len(r.Chunks))
+	for i, chunk := range r.Chunks {
+		collections[i] = ChunkCollection{Chunk: chunk}
+
+		switch {
+		case chunk.CollectionIndex < uint(len(guarantees)):
+			collections[i].CollectionID = guarantees[chunk.CollectionIndex].CollectionID
+		case chunk.CollectionIndex == uint(len(guarantees)) && i == len(r.Chunks)-1:
+			collections[i].SystemChunk = true
+		default:
+			anomalies = append(anomalies, ChunkAnomaly{
+				ChunkIndex: i,
+				Kind:       ChunkAnomalyCollectionMismatch,
+				Message: fmt.Sprintf(
+					"collection index %d does not match any of the %d collections",
+					chunk.CollectionIndex,
+					len(guarantees),
+				),
+			})
+		}
+	}
+
+	return collections, anomalies
+}
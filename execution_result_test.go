@@ -0,0 +1,197 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2023 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flow
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// The expected encodings below are written out from the RLP layout of the protocol entities,
+// independently of the encoding code, and the IDs are the SHA3-256 hashes of these encodings.
+
+func TestCollection_ID(t *testing.T) {
+	collection := Collection{TransactionIDs: []Identifier{
+		HexToID(strings.Repeat("11", 32)),
+		HexToID(strings.Repeat("22", 32)),
+	}}
+
+	// [[tx1, tx2]]
+	expected := "f844" + "f842" +
+		"a0" + strings.Repeat("11", 32) +
+		"a0" + strings.Repeat("22", 32)
+
+	assert.Equal(t, expected, hex.EncodeToString(collection.Encode()))
+	assert.Equal(t, "2cdcad8ef9150b85f59ce918c644d4faa69e8e55f4c907fdf24d26ee9c8eb811", collection.ID().Hex())
+	assert.Equal(t, HashToID(hashSHA3(mustHex(t, expected))), collection.ID())
+
+	other := Collection{TransactionIDs: []Identifier{collection.TransactionIDs[1], collection.TransactionIDs[0]}}
+	assert.NotEqual(t, collection.ID(), other.ID())
+}
+
+// testChunk returns a chunk of block 0xcc executing 3 transactions of its collection at index 0.
+func testChunk() *Chunk {
+	return &Chunk{
+		CollectionIndex:      0,
+		StartState:           HexToStateCommitment("aa"),
+		EventCollection:      HexToID("bb"),
+		BlockID:              HexToID("cc"),
+		TotalComputationUsed: 1000,
+		NumberOfTransactions: 3,
+		Index:                0,
+		EndState:             HexToStateCommitment("dd"),
+	}
+}
+
+// testChunkBodyEncoding is the encoding of the body of testChunk:
+// [collection index, start state, event collection, block ID, computation used, transaction count]
+const testChunkBodyEncoding = "f868" +
+	"80" +
+	"a0aa00000000000000000000000000000000000000000000000000000000000000" +
+	"a0bb00000000000000000000000000000000000000000000000000000000000000" +
+	"a0cc00000000000000000000000000000000000000000000000000000000000000" +
+	"8203e8" +
+	"03"
+
+func TestChunk_ID(t *testing.T) {
+	chunk := testChunk()
+
+	assert.Equal(t, "8341424c68bf3e1ab7497851282cbabec20003d79b4373faa94dc675da00e0da", chunk.ID().Hex())
+	assert.Equal(t, HashToID(hashSHA3(mustHex(t, testChunkBodyEncoding))), chunk.ID())
+
+	// the index and end state are not part of the chunk ID
+	other := *chunk
+	other.Index = 2
+	other.EndState = StateCommitment{5}
+	assert.Equal(t, chunk.ID(), other.ID())
+
+	other.EventCollection = Identifier{6}
+	assert.NotEqual(t, chunk.ID(), other.ID())
+}
+
+func TestExecutionResult_ID(t *testing.T) {
+	result := ExecutionResult{
+		PreviousResultID: HexToID("ee"),
+		BlockID:          HexToID("cc"),
+		Chunks:           []*Chunk{testChunk()},
+		ExecutionDataID:  HexToID("ff"),
+	}
+
+	// [previous result ID, block ID, [[chunk body, index, end state]], service events, execution data ID]
+	expected := "f8f4" +
+		"a0ee00000000000000000000000000000000000000000000000000000000000000" +
+		"a0cc00000000000000000000000000000000000000000000000000000000000000" +
+		"f88e" + "f88c" + testChunkBodyEncoding + "80" +
+		"a0dd00000000000000000000000000000000000000000000000000000000000000" +
+		"c0" +
+		"a0ff00000000000000000000000000000000000000000000000000000000000000"
+
+	id, err := result.ID()
+	require.NoError(t, err)
+	assert.Equal(t, "eada77ee2e78ef1b3fd6c8d5caedf8339214815f4e37d1493e9fc7aaf55f075b", id.Hex())
+	assert.Equal(t, HashToID(hashSHA3(mustHex(t, expected))), id)
+
+	// unlike the chunk ID, the result ID commits to the chunk end states
+	result.Chunks[0].EndState = StateCommitment{4}
+	other, err := result.ID()
+	require.NoError(t, err)
+	assert.NotEqual(t, id, other)
+
+	result.ExecutionDataID = EmptyID
+	_, err = result.ID()
+	assert.ErrorIs(t, err, ErrMissingExecutionDataID)
+
+	result.ServiceEvents = []*ServiceEvent{{Type: "flow.EpochSetup"}}
+	_, err = result.ID()
+	assert.ErrorIs(t, err, ErrServiceEventsNotHashable)
+}
+
+func mustHex(t *testing.T, h string) []byte {
+	b, err := hex.DecodeString(h)
+	require.NoError(t, err)
+	return b
+}
+
+func testResult() (ExecutionResult, Block) {
+	blockID := Identifier{9}
+	block := Block{
+		BlockHeader: BlockHeader{ID: blockID},
+		BlockPayload: BlockPayload{
+			CollectionGuarantees: []*CollectionGuarantee{{CollectionID: Identifier{1}}, {CollectionID: Identifier{2}}},
+		},
+	}
+
+	result := ExecutionResult{
+		BlockID: blockID,
+		Chunks: []*Chunk{
+			{CollectionIndex: 0, Index: 0, BlockID: blockID, StartState: StateCommitment{0}, EndState: StateCommitment{1}, TotalComputationUsed: 10, NumberOfTransactions: 2},
+			{CollectionIndex: 1, Index: 1, BlockID: blockID, StartState: StateCommitment{1}, EndState: StateCommitment{2}, TotalComputationUsed: 30, NumberOfTransactions: 3},
+			{CollectionIndex: 2, Index: 2, BlockID: blockID, StartState: StateCommitment{2}, EndState: StateCommitment{3}, TotalComputationUsed: 5, NumberOfTransactions: 1},
+		},
+	}
+
+	return result, block
+}
+
+func TestExecutionResult_ChunkCollections(t *testing.T) {
+	result, block := testResult()
+
+	collections, anomalies := result.ChunkCollections(block)
+	assert.Empty(t, anomalies)
+	require.Len(t, collections, 3)
+	assert.Equal(t, Identifier{1}, collections[0].CollectionID)
+	assert.Equal(t, Identifier{2}, collections[1].CollectionID)
+	assert.True(t, collections[2].SystemChunk)
+
+	result.Chunks[1].CollectionIndex = 5
+	_, anomalies = result.ChunkCollections(block)
+	require.Len(t, anomalies, 1)
+	assert.Equal(t, ChunkAnomalyCollectionMismatch, anomalies[0].Kind)
+	assert.Equal(t, 1, anomalies[0].ChunkIndex)
+}
+
+func TestExecutionResult_ChunkAnomalies(t *testing.T) {
+	result, _ := testResult()
+	assert.Empty(t, result.ChunkAnomalies())
+
+	result.Chunks[2].StartState = StateCommitment{7}
+	result.Chunks[1].Index = 4
+
+	anomalies := result.ChunkAnomalies()
+	require.Len(t, anomalies, 2)
+	assert.Equal(t, ChunkAnomaly{ChunkIndex: 1, Kind: ChunkAnomalyIndexMismatch, Message: "chunk at position 1 has index 4"}, anomalies[0])
+	assert.Equal(t, ChunkAnomalyStateDiscontinuity, anomalies[1].Kind)
+	assert.Equal(t, 2, anomalies[1].ChunkIndex)
+}
+
+func TestExecutionResult_ComputationSummary(t *testing.T) {
+	result, _ := testResult()
+
+	summary := result.ComputationSummary()
+	assert.Equal(t, ComputationSummary{
+		TotalComputationUsed: 45,
+		TotalTransactions:    6,
+		ChunkComputationUsed: []uint64{10, 30, 5},
+		MaxChunkIndex:        1,
+	}, summary)
+}
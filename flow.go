@@ -19,9 +19,15 @@
 package flow
 
 import (
+	"database/sql/driver"
+	"encoding"
 	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
 
 	"github.com/ethereum/go-ethereum/rlp"
+	"golang.org/x/crypto/sha3"
 )
 
 // An Identifier is a 32-byte unique identifier for an entity.
@@ -53,11 +59,81 @@ func BytesToID(b []byte) Identifier {
 }
 
 // HexToID constructs an identifier from a hexadecimal string.
+//
+// Invalid hex is decoded as the empty identifier, use ParseIdentifier to check the input.
 func HexToID(h string) Identifier {
 	b, _ := hex.DecodeString(h)
 	return BytesToID(b)
 }
 
+// ParseIdentifier parses the hex representation of an identifier, with an optional 0x prefix.
+//
+// Unlike HexToID, an error is returned if the input is not valid hex or does not have
+// exactly 64 hex digits.
+func ParseIdentifier(h string) (Identifier, error) {
+	trimmed := strings.TrimPrefix(h, "0x")
+	if len(trimmed) != 2*len(EmptyID) {
+		return EmptyID, fmt.Errorf("invalid identifier %q: expected %d hex digits, got %d", h, 2*len(EmptyID), len(trimmed))
+	}
+
+	b, err := hex.DecodeString(trimmed)
+	if err != nil {
+		return EmptyID, fmt.Errorf("invalid identifier %q: %w", h, err)
+	}
+	return BytesToID(b), nil
+}
+
+// MarshalText encodes the identifier as hex, without prefix.
+//
+// Identifiers are thus encoded as JSON strings, including as map keys.
+func (i Identifier) MarshalText() ([]byte, error) {
+	return []byte(i.Hex()), nil
+}
+
+// UnmarshalText decodes the identifier as ParseIdentifier does.
+func (i *Identifier) UnmarshalText(text []byte) error {
+	id, err := ParseIdentifier(string(text))
+	if err != nil {
+		return err
+	}
+	*i = id
+	return nil
+}
+
+func (i Identifier) MarshalJSON() ([]byte, error) {
+	return marshalJSONText(i)
+}
+
+// UnmarshalJSON decodes a JSON string as ParseIdentifier does. A JSON null is ignored.
+func (i *Identifier) UnmarshalJSON(data []byte) error {
+	return unmarshalJSONText(data, i)
+}
+
+// Value implements driver.Valuer, the identifier is stored as its 32 bytes.
+func (i Identifier) Value() (driver.Value, error) {
+	return i.Bytes(), nil
+}
+
+// Scan implements sql.Scanner. It accepts the 32 bytes of the identifier, or its hex
+// representation as a string or bytes, and stores the empty identifier for NULL.
+func (i *Identifier) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*i = EmptyID
+		return nil
+	case []byte:
+		if len(v) == len(EmptyID) {
+			*i = BytesToID(v)
+			return nil
+		}
+		return i.UnmarshalText(v)
+	case string:
+		return i.UnmarshalText([]byte(v))
+	default:
+		return fmt.Errorf("cannot scan %T into an identifier", src)
+	}
+}
+
 // HashToID constructs an identifier from a 32-byte hash.
 func HashToID(hash []byte) Identifier {
 	return BytesToID(hash)
@@ -80,6 +156,51 @@ func HashToStateCommitment(hash []byte) StateCommitment {
 	return StateCommitment(HashToID(hash))
 }
 
+// ParseStateCommitment parses the hex representation of a state commitment as ParseIdentifier does.
+func ParseStateCommitment(h string) (StateCommitment, error) {
+	id, err := ParseIdentifier(h)
+	return StateCommitment(id), err
+}
+
+// Hex returns the hexadecimal string representation of this state commitment.
+func (s StateCommitment) Hex() string {
+	return Identifier(s).Hex()
+}
+
+// String returns the string representation of this state commitment.
+func (s StateCommitment) String() string {
+	return s.Hex()
+}
+
+// MarshalText encodes the state commitment as hex, without prefix.
+func (s StateCommitment) MarshalText() ([]byte, error) {
+	return Identifier(s).MarshalText()
+}
+
+// UnmarshalText decodes the state commitment as ParseStateCommitment does.
+func (s *StateCommitment) UnmarshalText(text []byte) error {
+	return (*Identifier)(s).UnmarshalText(text)
+}
+
+func (s StateCommitment) MarshalJSON() ([]byte, error) {
+	return marshalJSONText(s)
+}
+
+// UnmarshalJSON decodes a JSON string as ParseStateCommitment does. A JSON null is ignored.
+func (s *StateCommitment) UnmarshalJSON(data []byte) error {
+	return unmarshalJSONText(data, s)
+}
+
+// Value implements driver.Valuer, the state commitment is stored as its 32 bytes.
+func (s StateCommitment) Value() (driver.Value, error) {
+	return Identifier(s).Value()
+}
+
+// Scan implements sql.Scanner, see Identifier.Scan.
+func (s *StateCommitment) Scan(src any) error {
+	return (*Identifier)(s).Scan(src)
+}
+
 // A ChainID is a unique identifier for a specific Flow network instance.
 //
 // Chain IDs are used used to prevent replay attacks and to support network-specific address generation.
@@ -116,6 +237,38 @@ func (id ChainID) String() string {
 	return string(id)
 }
 
+// makeID computes the ID of an entity as the protocol does, the SHA3-256 hash of its RLP encoding.
+func makeID(v interface{}) Identifier {
+	return HashToID(hashSHA3(mustRLPEncode(v)))
+}
+
+// marshalJSONText encodes the text representation of v as a JSON string.
+func marshalJSONText(v encoding.TextMarshaler) ([]byte, error) {
+	text, err := v.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(string(text))
+}
+
+// unmarshalJSONText decodes a JSON string with the text decoding of v. A JSON null is ignored.
+func unmarshalJSONText(data []byte, v encoding.TextUnmarshaler) error {
+	if string(data) == "null" {
+		return nil
+	}
+
+	var text string
+	if err := json.Unmarshal(data, &text); err != nil {
+		return err
+	}
+	return v.UnmarshalText([]byte(text))
+}
+
+func hashSHA3(b []byte) []byte {
+	h := sha3.Sum256(b)
+	return h[:]
+}
+
 func rlpEncode(v interface{}) ([]byte, error) {
 	return rlp.EncodeToBytes(v)
 }
@@ -125,15 +278,6 @@ func rlpDecode(b []byte, v interface{}) error {
 }
 
 func mustRLPEncode(v interface{}) []byte {
-	// Note(sideninja): This is a temporary workaround until cadence defines canonical format addressing the issue https://github.com/onflow/flow-go-sdk/issues/286
-	if tx, ok := v.(payloadCanonicalForm); ok {
-		for _, arg := range tx.Arguments {
-			if arg[len(arg)-1] == byte(10) {
-				arg = arg[:len(tx.Arguments)-1]
-			}
-		}
-	}
-
 	b, err := rlpEncode(v)
 	if err != nil {
 		panic(err)
@@ -0,0 +1,184 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2023 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flow
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseIdentifier(t *testing.T) {
+	h := strings.Repeat("0a", 32)
+	expected := HexToID(h)
+
+	for _, s := range []string{h, "0x" + h} {
+		id, err := ParseIdentifier(s)
+		require.NoError(t, err)
+		assert.Equal(t, expected, id)
+	}
+
+	for _, s := range []string{"", "0x", h[2:], h + "00", "zz" + h[2:]} {
+		_, err := ParseIdentifier(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestIdentifierEncodings(t *testing.T) {
+	id := HexToID(strings.Repeat("0a", 32))
+
+	t.Run("JSON", func(t *testing.T) {
+		data, err := json.Marshal(map[Identifier]Identifier{id: id})
+		require.NoError(t, err)
+		assert.Equal(t, `{"`+id.Hex()+`":"`+id.Hex()+`"}`, string(data))
+
+		var out map[Identifier]Identifier
+		require.NoError(t, json.Unmarshal(data, &out))
+		assert.Equal(t, map[Identifier]Identifier{id: id}, out)
+
+		var invalid Identifier
+		assert.Error(t, json.Unmarshal([]byte(`"0a"`), &invalid))
+	})
+
+	t.Run("SQL", func(t *testing.T) {
+		value, err := id.Value()
+		require.NoError(t, err)
+		assert.Equal(t, id.Bytes(), value)
+
+		for _, src := range []any{value, id.Hex(), []byte("0x" + id.Hex())} {
+			var scanned Identifier
+			require.NoError(t, scanned.Scan(src))
+			assert.Equal(t, id, scanned)
+		}
+
+		scanned := id
+		require.NoError(t, scanned.Scan(nil))
+		assert.Equal(t, EmptyID, scanned)
+
+		assert.Error(t, scanned.Scan(42))
+		assert.Error(t, scanned.Scan(id.Hex()[2:]))
+	})
+}
+
+// encodable is implemented by the pointers to the types which can be stored in databases.
+type encodable interface {
+	encoding.TextMarshaler
+	encoding.TextUnmarshaler
+	json.Marshaler
+	json.Unmarshaler
+	driver.Valuer
+	sql.Scanner
+}
+
+func TestEncodingsRoundTrip(t *testing.T) {
+	id := HexToID(strings.Repeat("0a", 32))
+	address := ServiceAddress(Mainnet)
+	commitment := StateCommitment(id)
+	transactionStatus := TransactionStatusSealed
+	blockStatus := BlockStatusFinalized
+
+	for _, test := range []struct {
+		name  string
+		value encodable
+		new   func() encodable
+	}{
+		{"Identifier", &id, func() encodable { return new(Identifier) }},
+		{"Address", &address, func() encodable { return new(Address) }},
+		{"StateCommitment", &commitment, func() encodable { return new(StateCommitment) }},
+		{"TransactionStatus", &transactionStatus, func() encodable { return new(TransactionStatus) }},
+		{"BlockStatus", &blockStatus, func() encodable { return new(BlockStatus) }},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			text, err := test.value.MarshalText()
+			require.NoError(t, err)
+			fromText := test.new()
+			require.NoError(t, fromText.UnmarshalText(text))
+			assert.Equal(t, test.value, fromText)
+
+			data, err := json.Marshal(test.value)
+			require.NoError(t, err)
+			assert.Equal(t, `"`+string(text)+`"`, string(data))
+			fromJSON := test.new()
+			require.NoError(t, json.Unmarshal(data, fromJSON))
+			assert.Equal(t, test.value, fromJSON)
+
+			value, err := test.value.Value()
+			require.NoError(t, err)
+			fromSQL := test.new()
+			require.NoError(t, fromSQL.Scan(value))
+			assert.Equal(t, test.value, fromSQL)
+
+			// drivers may return text columns as bytes
+			fromBytes := test.new()
+			require.NoError(t, fromBytes.Scan(text))
+			assert.Equal(t, test.value, fromBytes)
+
+			// JSON null is ignored
+			require.NoError(t, json.Unmarshal([]byte("null"), fromJSON))
+			assert.Equal(t, test.value, fromJSON)
+
+			assert.Error(t, test.new().UnmarshalText([]byte("invalid")))
+			assert.Error(t, test.new().Scan(1.5))
+		})
+	}
+}
+
+func TestStatusEncodings(t *testing.T) {
+	t.Run("TransactionStatus", func(t *testing.T) {
+		status, err := ParseTransactionStatus("EXECUTED")
+		require.NoError(t, err)
+		assert.Equal(t, TransactionStatusExecuted, status)
+
+		// integer columns and JSON numbers are accepted
+		require.NoError(t, status.Scan(int64(TransactionStatusExpired)))
+		assert.Equal(t, TransactionStatusExpired, status)
+		require.NoError(t, json.Unmarshal([]byte("2"), &status))
+		assert.Equal(t, TransactionStatusFinalized, status)
+
+		assert.Error(t, status.Scan(int64(42)))
+		_, err = TransactionStatus(42).MarshalText()
+		assert.Error(t, err)
+		assert.Equal(t, "TransactionStatus(42)", TransactionStatus(42).String())
+	})
+
+	t.Run("BlockStatus", func(t *testing.T) {
+		assert.Equal(t, "BLOCK_SEALED", BlockStatusSealed.String())
+
+		status, err := ParseBlockStatus("BLOCK_SEALED")
+		require.NoError(t, err)
+		assert.Equal(t, BlockStatusSealed, status)
+		assert.Equal(t, BlockStatusFromString(BlockStatusFinalized.String()), BlockStatusFinalized)
+
+		_, err = ParseBlockStatus("SEALED")
+		assert.Error(t, err)
+
+		require.NoError(t, json.Unmarshal([]byte("1"), &status))
+		assert.Equal(t, BlockStatusFinalized, status)
+		assert.Error(t, status.Scan(int64(-1)))
+
+		_, err = BlockStatus(3).Value()
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,441 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2023 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flow
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// PartialTransactionVersion is the version of the partially signed transaction encoding.
+const PartialTransactionVersion = 1
+
+// ErrPayloadMismatch is returned when merging partially signed transactions with different payloads.
+var ErrPayloadMismatch = errors.New("partially signed transactions have different payloads")
+
+// A SignerRole is a role an account has in a transaction.
+type SignerRole string
+
+const (
+	SignerRoleProposer   SignerRole = "proposer"
+	SignerRolePayer      SignerRole = "payer"
+	SignerRoleAuthorizer SignerRole = "authorizer"
+)
+
+// A PartialSigner is an account which is required to sign a partially signed transaction.
+type PartialSigner struct {
+	Address Address      `json:"address"`
+	Roles   []SignerRole `json:"roles"`
+	// KeyIndices are the keys of the account expected to sign, any key can sign if empty.
+	KeyIndices []int `json:"key_indices,omitempty"`
+}
+
+// HasRole returns true if the signer has the given role.
+func (s PartialSigner) HasRole(role SignerRole) bool {
+	for _, r := range s.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// Envelope returns true if the signer signs the transaction envelope, which is the case for the payer.
+func (s PartialSigner) Envelope() bool {
+	return s.HasRole(SignerRolePayer)
+}
+
+// A MissingSignature is a signature still required to finalize a partially signed transaction.
+type MissingSignature struct {
+	Address Address
+	// KeyIndex is the key expected to sign, or -1 if any key of the account can sign.
+	KeyIndex int
+	// Envelope is true if the signature is an envelope signature, otherwise it is a payload signature.
+	Envelope bool
+	Roles    []SignerRole
+}
+
+func (m MissingSignature) String() string {
+	key := "any key"
+	if m.KeyIndex >= 0 {
+		key = fmt.Sprintf("key %d", m.KeyIndex)
+	}
+
+	return fmt.Sprintf("%s signature of %s with %s", signatureKind(m.Envelope), m.Address, key)
+}
+
+// MissingSignaturesError is returned when finalizing a transaction which is not fully signed.
+type MissingSignaturesError struct {
+	Missing []MissingSignature
+}
+
+func (e MissingSignaturesError) Error() string {
+	missing := make([]string, len(e.Missing))
+	for i, m := range e.Missing {
+		missing[i] = m.String()
+	}
+	return fmt.Sprintf("transaction is missing signatures: %s", strings.Join(missing, ", "))
+}
+
+// SignatureConflictError is returned when merging copies of a partial transaction carrying
+// different signatures for the same account key.
+type SignatureConflictError struct {
+	Address  Address
+	KeyIndex int
+	Envelope bool
+}
+
+func (e SignatureConflictError) Error() string {
+	return fmt.Sprintf(
+		"conflicting %s signatures of %s with key %d",
+		signatureKind(e.Envelope),
+		e.Address,
+		e.KeyIndex,
+	)
+}
+
+// A PartialTransaction is a transaction which is being signed by several parties.
+//
+// It carries the transaction, the signatures collected so far and the accounts required to sign.
+// Copies of a partial transaction can be signed independently and merged back together.
+type PartialTransaction struct {
+	Transaction *Transaction    `json:"transaction"`
+	Signers     []PartialSigner `json:"signers"`
+}
+
+// NewPartialTransaction returns a partial transaction requiring signatures of all the transaction signers.
+//
+// The proposer is expected to sign with the proposal key, the other accounts can sign with any key
+// unless restricted using SetSignerKeys.
+func NewPartialTransaction(tx *Transaction) *PartialTransaction {
+	signers := make([]PartialSigner, 0)
+	index := make(map[Address]int)
+
+	addRole := func(address Address, role SignerRole) {
+		i, ok := index[address]
+		if !ok {
+			i = len(signers)
+			index[address] = i
+			signers = append(signers, PartialSigner{Address: address})
+		}
+		if !signers[i].HasRole(role) {
+			signers[i].Roles = append(signers[i].Roles, role)
+		}
+	}
+
+	if tx.ProposalKey.Address != EmptyAddress {
+		addRole(tx.ProposalKey.Address, SignerRoleProposer)
+		signers[0].KeyIndices = []int{tx.ProposalKey.KeyIndex}
+	}
+	if tx.Payer != EmptyAddress {
+		addRole(tx.Payer, SignerRolePayer)
+	}
+	for _, authorizer := range tx.Authorizers {
+		addRole(authorizer, SignerRoleAuthorizer)
+	}
+
+	return &PartialTransaction{
+		Transaction: tx,
+		Signers:     signers,
+	}
+}
+
+// SetSignerKeys sets the keys the given account is expected to sign with.
+func (p *PartialTransaction) SetSignerKeys(address Address, keyIndices ...int) error {
+	for i, signer := range p.Signers {
+		if signer.Address == address {
+			p.Signers[i].KeyIndices = keyIndices
+			return nil
+		}
+	}
+	return fmt.Errorf("account %s is not a signer of the transaction", address)
+}
+
+// MissingSignatures returns the signatures which are still required to finalize the transaction.
+func (p *PartialTransaction) MissingSignatures() []MissingSignature {
+	missing := make([]MissingSignature, 0)
+
+	for _, signer := range p.Signers {
+		envelope := signer.Envelope()
+		signatures := p.Transaction.PayloadSignatures
+		if envelope {
+			signatures = p.Transaction.EnvelopeSignatures
+		}
+
+		if len(signer.KeyIndices) == 0 {
+			if !hasSignature(signatures, signer.Address, -1) {
+				missing = append(missing, MissingSignature{
+					Address:  signer.Address,
+					KeyIndex: -1,
+					Envelope: envelope,
+					Roles:    signer.Roles,
+				})
+			}
+			continue
+		}
+
+		for _, keyIndex := range signer.KeyIndices {
+			if !hasSignature(signatures, signer.Address, keyIndex) {
+				missing = append(missing, MissingSignature{
+					Address:  signer.Address,
+					KeyIndex: keyIndex,
+					Envelope: envelope,
+					Roles:    signer.Roles,
+				})
+			}
+		}
+	}
+
+	return missing
+}
+
+// RemainingRoles returns the roles of the signers which still have to sign the transaction.
+func (p *PartialTransaction) RemainingRoles() []SignerRole {
+	remaining := make([]SignerRole, 0)
+	seen := make(map[SignerRole]struct{})
+
+	for _, m := range p.MissingSignatures() {
+		for _, role := range m.Roles {
+			if _, ok := seen[role]; ok {
+				continue
+			}
+			seen[role] = struct{}{}
+			remaining = append(remaining, role)
+		}
+	}
+
+	return remaining
+}
+
+// Complete returns true if all the required signatures have been collected.
+func (p *PartialTransaction) Complete() bool {
+	return len(p.MissingSignatures()) == 0
+}
+
+// Merge adds the signatures of other copies of the partial transaction.
+//
+// All copies must have the same payload, otherwise ErrPayloadMismatch is returned. Envelope signatures
+// can only be merged from copies that have all of the merged payload signatures, as the envelope
+// signature covers them. A SignatureConflictError is returned if copies carry different signatures
+// for the same account key.
+//
+// Errors refer to the other copies by their index in others, and to p as the receiver.
+func (p *PartialTransaction) Merge(others ...*PartialTransaction) error {
+	payload := p.Transaction.PayloadMessage()
+
+	for i, other := range others {
+		if !bytes.Equal(payload, other.Transaction.PayloadMessage()) {
+			return fmt.Errorf("failed to merge partial transaction %d: %w", i, ErrPayloadMismatch)
+		}
+	}
+
+	// merge into a copy so that p is left unchanged on error
+	tx := *p.Transaction
+	tx.PayloadSignatures = append([]TransactionSignature(nil), tx.PayloadSignatures...)
+	tx.EnvelopeSignatures = append([]TransactionSignature(nil), tx.EnvelopeSignatures...)
+
+	for _, other := range others {
+		for _, sig := range other.Transaction.PayloadSignatures {
+			existing, ok := findSignature(tx.PayloadSignatures, sig.Address, sig.KeyIndex)
+			if !ok {
+				tx.AddPayloadSignature(sig.Address, sig.KeyIndex, sig.Signature)
+				continue
+			}
+			if !bytes.Equal(existing.Signature, sig.Signature) {
+				return SignatureConflictError{Address: sig.Address, KeyIndex: sig.KeyIndex}
+			}
+		}
+	}
+
+	envelopeSources := append([]*PartialTransaction{p}, others...)
+	for i, source := range envelopeSources {
+		if len(source.Transaction.EnvelopeSignatures) == 0 {
+			continue
+		}
+		if !sameSignatures(tx.PayloadSignatures, source.Transaction.PayloadSignatures) {
+			name := "the receiver"
+			if i > 0 {
+				name = fmt.Sprintf("partial transaction %d", i-1)
+			}
+			return fmt.Errorf("envelope signatures of %s do not cover all payload signatures", name)
+		}
+		for _, sig := range source.Transaction.EnvelopeSignatures {
+			existing, ok := findSignature(tx.EnvelopeSignatures, sig.Address, sig.KeyIndex)
+			if !ok {
+				tx.AddEnvelopeSignature(sig.Address, sig.KeyIndex, sig.Signature)
+				continue
+			}
+			if !bytes.Equal(existing.Signature, sig.Signature) {
+				return SignatureConflictError{Address: sig.Address, KeyIndex: sig.KeyIndex, Envelope: true}
+			}
+		}
+	}
+
+	*p.Transaction = tx
+	return nil
+}
+
+// Finalize returns the fully signed transaction.
+//
+// An error is returned if signatures are missing or if the transaction contains signatures
+// from accounts which are not signers of the transaction.
+func (p *PartialTransaction) Finalize() (*Transaction, error) {
+	if missing := p.MissingSignatures(); len(missing) > 0 {
+		return nil, MissingSignaturesError{Missing: missing}
+	}
+
+	for _, signatures := range [][]TransactionSignature{p.Transaction.PayloadSignatures, p.Transaction.EnvelopeSignatures} {
+		for _, sig := range signatures {
+			if sig.SignerIndex < 0 {
+				return nil, fmt.Errorf("signature of account %s which is not a signer of the transaction", sig.Address)
+			}
+		}
+	}
+
+	return p.Transaction, nil
+}
+
+type partialSignerCanonicalForm struct {
+	Address    []byte
+	Roles      []string
+	KeyIndices []uint
+}
+
+type partialTransactionCanonicalForm struct {
+	Version     uint
+	Transaction []byte
+	Signers     []partialSignerCanonicalForm
+}
+
+// Encode returns the RLP byte representation of the partial transaction.
+//
+// The transaction is included in the format returned by Transaction.Encode.
+func (p *PartialTransaction) Encode() []byte {
+	signers := make([]partialSignerCanonicalForm, len(p.Signers))
+	for i, signer := range p.Signers {
+		roles := make([]string, len(signer.Roles))
+		for j, role := range signer.Roles {
+			roles[j] = string(role)
+		}
+
+		keyIndices := make([]uint, len(signer.KeyIndices))
+		for j, keyIndex := range signer.KeyIndices {
+			keyIndices[j] = uint(keyIndex)
+		}
+
+		signers[i] = partialSignerCanonicalForm{
+			Address:    signer.Address.Bytes(),
+			Roles:      roles,
+			KeyIndices: keyIndices,
+		}
+	}
+
+	return mustRLPEncode(&partialTransactionCanonicalForm{
+		Version:     PartialTransactionVersion,
+		Transaction: p.Transaction.Encode(),
+		Signers:     signers,
+	})
+}
+
+// DecodePartialTransaction decodes a partial transaction encoded with PartialTransaction.Encode.
+func DecodePartialTransaction(b []byte) (*PartialTransaction, error) {
+	var temp partialTransactionCanonicalForm
+	if err := rlp.DecodeBytes(b, &temp); err != nil {
+		return nil, fmt.Errorf("failed to decode partial transaction: %w", err)
+	}
+
+	if temp.Version != PartialTransactionVersion {
+		return nil, fmt.Errorf("unsupported partial transaction version %d", temp.Version)
+	}
+
+	tx, err := DecodeTransaction(temp.Transaction)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode transaction: %w", err)
+	}
+
+	signers := make([]PartialSigner, len(temp.Signers))
+	for i, signer := range temp.Signers {
+		roles := make([]SignerRole, len(signer.Roles))
+		for j, role := range signer.Roles {
+			roles[j] = SignerRole(role)
+		}
+
+		var keyIndices []int
+		for _, keyIndex := range signer.KeyIndices {
+			keyIndices = append(keyIndices, int(keyIndex))
+		}
+
+		signers[i] = PartialSigner{
+			Address:    BytesToAddress(signer.Address),
+			Roles:      roles,
+			KeyIndices: keyIndices,
+		}
+	}
+
+	return &PartialTransaction{
+		Transaction: tx,
+		Signers:     signers,
+	}, nil
+}
+
+// hasSignature returns true if the signatures contain a signature of the given account key,
+// or of any key of the account if keyIndex is -1.
+func hasSignature(signatures []TransactionSignature, address Address, keyIndex int) bool {
+	for _, sig := range signatures {
+		if sig.Address == address && (keyIndex < 0 || sig.KeyIndex == keyIndex) {
+			return true
+		}
+	}
+	return false
+}
+
+// findSignature returns the signature of the given account key.
+func findSignature(signatures []TransactionSignature, address Address, keyIndex int) (TransactionSignature, bool) {
+	for _, sig := range signatures {
+		if sig.Address == address && sig.KeyIndex == keyIndex {
+			return sig, true
+		}
+	}
+	return TransactionSignature{}, false
+}
+
+// sameSignatures returns true if both lists contain the same signatures, regardless of their order.
+func sameSignatures(a, b []TransactionSignature) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for _, sigB := range b {
+		found := false
+		for _, sigA := range a {
+			if sigA.Address == sigB.Address && sigA.KeyIndex == sigB.KeyIndex && bytes.Equal(sigA.Signature, sigB.Signature) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
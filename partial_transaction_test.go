@@ -0,0 +1,164 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2023 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func unsignedTestTransaction() *Transaction {
+	return NewTransaction().
+		SetScript([]byte(`transaction { prepare(a: AuthAccount, b: AuthAccount) {} }`)).
+		SetReferenceBlockID(Identifier{1}).
+		SetProposalKey(HexToAddress("01"), 2, 7).
+		SetPayer(HexToAddress("03")).
+		AddAuthorizer(HexToAddress("01")).
+		AddAuthorizer(HexToAddress("02"))
+}
+
+func TestPartialTransaction_MissingSignatures(t *testing.T) {
+	p := NewPartialTransaction(unsignedTestTransaction())
+
+	assert.Equal(t, []PartialSigner{
+		{Address: HexToAddress("01"), Roles: []SignerRole{SignerRoleProposer, SignerRoleAuthorizer}, KeyIndices: []int{2}},
+		{Address: HexToAddress("03"), Roles: []SignerRole{SignerRolePayer}},
+		{Address: HexToAddress("02"), Roles: []SignerRole{SignerRoleAuthorizer}},
+	}, p.Signers)
+
+	require.NoError(t, p.SetSignerKeys(HexToAddress("02"), 0, 1))
+	assert.Error(t, p.SetSignerKeys(HexToAddress("04"), 0))
+
+	missing := p.MissingSignatures()
+	require.Len(t, missing, 4)
+	assert.Equal(t, MissingSignature{
+		Address:  HexToAddress("03"),
+		KeyIndex: -1,
+		Envelope: true,
+		Roles:    []SignerRole{SignerRolePayer},
+	}, missing[1])
+
+	p.Transaction.AddPayloadSignature(HexToAddress("01"), 2, []byte{1})
+	p.Transaction.AddPayloadSignature(HexToAddress("02"), 0, []byte{2})
+
+	missing = p.MissingSignatures()
+	require.Len(t, missing, 2)
+	assert.Equal(t, HexToAddress("03"), missing[0].Address)
+	assert.Equal(t, 1, missing[1].KeyIndex)
+	assert.Equal(t, []SignerRole{SignerRolePayer, SignerRoleAuthorizer}, p.RemainingRoles())
+	assert.False(t, p.Complete())
+}
+
+func TestPartialTransaction_Merge(t *testing.T) {
+	proposer := NewPartialTransaction(unsignedTestTransaction())
+	proposer.Transaction.AddPayloadSignature(HexToAddress("01"), 2, []byte{1})
+
+	authorizer := NewPartialTransaction(unsignedTestTransaction())
+	authorizer.Transaction.AddPayloadSignature(HexToAddress("02"), 0, []byte{2})
+
+	require.NoError(t, proposer.Merge(authorizer))
+	assert.Len(t, proposer.Transaction.PayloadSignatures, 2)
+	assert.Equal(t, []SignerRole{SignerRolePayer}, proposer.RemainingRoles())
+
+	_, err := proposer.Finalize()
+	var missingErr MissingSignaturesError
+	require.ErrorAs(t, err, &missingErr)
+	assert.Len(t, missingErr.Missing, 1)
+
+	// the payer signs a copy which includes all payload signatures
+	decoded, err := DecodePartialTransaction(proposer.Encode())
+	require.NoError(t, err)
+	decoded.Transaction.AddEnvelopeSignature(HexToAddress("03"), 0, []byte{3})
+
+	require.NoError(t, proposer.Merge(decoded))
+
+	tx, err := proposer.Finalize()
+	require.NoError(t, err)
+	assert.Equal(t, decoded.Transaction.Encode(), tx.Encode())
+
+	t.Run("payload mismatch", func(t *testing.T) {
+		other := NewPartialTransaction(unsignedTestTransaction().SetGasLimit(1))
+		err := proposer.Merge(NewPartialTransaction(unsignedTestTransaction()), other)
+		assert.ErrorIs(t, err, ErrPayloadMismatch)
+		assert.ErrorContains(t, err, "partial transaction 1")
+	})
+
+	t.Run("conflicting signatures", func(t *testing.T) {
+		merged := NewPartialTransaction(unsignedTestTransaction())
+		merged.Transaction.AddPayloadSignature(HexToAddress("01"), 2, []byte{1})
+
+		forged := NewPartialTransaction(unsignedTestTransaction())
+		forged.Transaction.AddPayloadSignature(HexToAddress("01"), 2, []byte{9})
+
+		err := merged.Merge(forged)
+		assert.Equal(t, SignatureConflictError{Address: HexToAddress("01"), KeyIndex: 2}, err)
+		assert.Equal(t, []byte{1}, merged.Transaction.PayloadSignatures[0].Signature)
+
+		// the same signature in several copies is not a conflict
+		same := NewPartialTransaction(unsignedTestTransaction())
+		same.Transaction.AddPayloadSignature(HexToAddress("01"), 2, []byte{1})
+		require.NoError(t, merged.Merge(same))
+
+		payer := NewPartialTransaction(unsignedTestTransaction())
+		payer.Transaction.AddPayloadSignature(HexToAddress("01"), 2, []byte{1})
+		payer.Transaction.AddEnvelopeSignature(HexToAddress("03"), 0, []byte{3})
+		forgedPayer := NewPartialTransaction(unsignedTestTransaction())
+		forgedPayer.Transaction.AddPayloadSignature(HexToAddress("01"), 2, []byte{1})
+		forgedPayer.Transaction.AddEnvelopeSignature(HexToAddress("03"), 0, []byte{4})
+
+		err = merged.Merge(payer, forgedPayer)
+		assert.Equal(t, SignatureConflictError{Address: HexToAddress("03"), KeyIndex: 0, Envelope: true}, err)
+		assert.Empty(t, merged.Transaction.EnvelopeSignatures)
+	})
+
+	t.Run("envelope signed before all payload signatures", func(t *testing.T) {
+		payer := NewPartialTransaction(unsignedTestTransaction())
+		payer.Transaction.AddEnvelopeSignature(HexToAddress("03"), 0, []byte{3})
+
+		merged := NewPartialTransaction(unsignedTestTransaction())
+		merged.Transaction.AddPayloadSignature(HexToAddress("01"), 2, []byte{1})
+
+		err := merged.Merge(NewPartialTransaction(unsignedTestTransaction()), payer)
+		assert.EqualError(t, err, "envelope signatures of partial transaction 1 do not cover all payload signatures")
+		assert.Empty(t, merged.Transaction.EnvelopeSignatures)
+
+		// the receiver is checked against the payload signatures of the other copies too
+		authorizer := NewPartialTransaction(unsignedTestTransaction())
+		authorizer.Transaction.AddPayloadSignature(HexToAddress("02"), 0, []byte{2})
+
+		err = payer.Merge(authorizer)
+		assert.EqualError(t, err, "envelope signatures of the receiver do not cover all payload signatures")
+		assert.Empty(t, payer.Transaction.PayloadSignatures)
+	})
+}
+
+func TestPartialTransaction_Encode(t *testing.T) {
+	p := NewPartialTransaction(unsignedTestTransaction())
+	require.NoError(t, p.SetSignerKeys(HexToAddress("02"), 0, 1))
+	p.Transaction.AddPayloadSignature(HexToAddress("02"), 1, []byte{2})
+
+	decoded, err := DecodePartialTransaction(p.Encode())
+	require.NoError(t, err)
+	assert.Equal(t, p, decoded)
+
+	_, err = DecodePartialTransaction([]byte{1, 2, 3})
+	assert.Error(t, err)
+}
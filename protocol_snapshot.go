@@ -0,0 +1,411 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2023 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flow
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// EpochPhase is the phase of the epoch lifecycle.
+type EpochPhase int
+
+const (
+	EpochPhaseUndefined EpochPhase = iota
+	EpochPhaseStaking
+	EpochPhaseSetup
+	EpochPhaseCommitted
+)
+
+func (p EpochPhase) String() string {
+	switch p {
+	case EpochPhaseStaking:
+		return "EpochPhaseStaking"
+	case EpochPhaseSetup:
+		return "EpochPhaseSetup"
+	case EpochPhaseCommitted:
+		return "EpochPhaseCommitted"
+	default:
+		return "EpochPhaseUndefined"
+	}
+}
+
+// QuorumCertificate is the certificate of the votes for a block by the consensus committee.
+type QuorumCertificate struct {
+	View          uint64
+	BlockID       Identifier
+	SignerIndices []byte
+	SigData       []byte
+}
+
+// SealingSegment is the chain of blocks from the block sealing the head of the snapshot
+// to the latest sealed block, as required by a node to bootstrap from the snapshot.
+type SealingSegment struct {
+	// Blocks are ordered by height, the last block is the head of the snapshot.
+	Blocks []*Block
+	// ExtraBlocks are the blocks preceding the segment, required to validate it.
+	ExtraBlocks []*Block
+	// LatestSeals maps the ID of each block of the segment to the ID of the latest seal as of this block.
+	LatestSeals map[Identifier]Identifier
+	// FirstSeal is the latest seal as of the first block, if it is not included in the segment.
+	FirstSeal *BlockSeal
+}
+
+// DKGParticipant is the random beacon key share of a consensus node.
+type DKGParticipant struct {
+	Index    uint
+	KeyShare []byte
+}
+
+// Epoch describes an epoch of the protocol state.
+type Epoch struct {
+	Counter            uint64
+	FirstView          uint64
+	FinalView          uint64
+	DKGPhase1FinalView uint64
+	DKGPhase2FinalView uint64
+	DKGPhase3FinalView uint64
+	RandomSource       []byte
+	// InitialIdentities are the nodes participating in the epoch at its start.
+	InitialIdentities []*Identity
+	// Clustering contains the IDs of the collection nodes in each cluster, indexed by cluster.
+	Clustering [][]Identifier
+	// DKGGroupKey and DKGParticipants are only set once the epoch is committed.
+	DKGGroupKey     []byte
+	DKGParticipants map[Identifier]DKGParticipant
+	// FirstHeight and FinalHeight are the range of heights of the epoch, only set once known.
+	FirstHeight *uint64
+	FinalHeight *uint64
+}
+
+// Epochs contains the epochs known to a protocol state snapshot.
+type Epochs struct {
+	Previous *Epoch
+	Current  *Epoch
+	Next     *Epoch
+}
+
+// SnapshotParams are the global parameters of the network.
+type SnapshotParams struct {
+	ChainID              ChainID
+	SporkID              Identifier
+	SporkRootBlockHeight uint64
+	ProtocolVersion      uint
+}
+
+// ProtocolStateSnapshot is the protocol state of the network as of the head block,
+// as returned by GetLatestProtocolStateSnapshot.
+type ProtocolStateSnapshot struct {
+	// Head is the block the snapshot is taken at.
+	Head *BlockHeader
+	// Identities are the nodes of the network as of the head block, with their role and weight.
+	Identities []*Identity
+	// LatestSeal is the latest seal as of the head block.
+	LatestSeal *BlockSeal
+	// SealingSegment is the segment of blocks from the latest sealed block to the head block.
+	SealingSegment *SealingSegment
+	// QuorumCertificate certifies the head block.
+	QuorumCertificate *QuorumCertificate
+	// Phase is the epoch phase as of the head block.
+	Phase  EpochPhase
+	Epochs Epochs
+	Params SnapshotParams
+}
+
+// DecodeProtocolStateSnapshot decodes the serialized protocol state snapshot returned by the access nodes.
+//
+// The IDs of the blocks are not part of the serialized snapshot. The ID of the head block is taken
+// from the quorum certificate and the IDs of the sealing segment blocks are recovered from the parent
+// IDs of their children.
+func DecodeProtocolStateSnapshot(b []byte) (*ProtocolStateSnapshot, error) {
+	var encoded encodableSnapshot
+	if err := json.Unmarshal(b, &encoded); err != nil {
+		return nil, fmt.Errorf("failed to decode protocol state snapshot: %w", err)
+	}
+
+	snapshot := &ProtocolStateSnapshot{
+		Identities: toIdentities(encoded.Identities),
+		LatestSeal: encoded.LatestSeal.toBlockSeal(),
+		Phase:      encoded.Phase,
+		Epochs: Epochs{
+			Previous: encoded.Epochs.Previous.toEpoch(),
+			Current:  encoded.Epochs.Current.toEpoch(),
+			Next:     encoded.Epochs.Next.toEpoch(),
+		},
+		Params: SnapshotParams{
+			ChainID:              encoded.Params.ChainID,
+			SporkID:              BytesToID(encoded.Params.SporkID),
+			SporkRootBlockHeight: encoded.Params.SporkRootBlockHeight,
+			ProtocolVersion:      encoded.Params.ProtocolVersion,
+		},
+	}
+
+	if qc := encoded.QuorumCertificate; qc != nil {
+		snapshot.QuorumCertificate = &QuorumCertificate{
+			View:          qc.View,
+			BlockID:       BytesToID(qc.BlockID),
+			SignerIndices: qc.SignerIndices,
+			SigData:       qc.SigData,
+		}
+	}
+
+	if encoded.Head != nil {
+		snapshot.Head = encoded.Head.toBlockHeader()
+		if qc := snapshot.QuorumCertificate; qc != nil && qc.View == snapshot.Head.View {
+			snapshot.Head.ID = qc.BlockID
+		}
+	}
+
+	if segment := encoded.SealingSegment; segment != nil {
+		snapshot.SealingSegment = &SealingSegment{
+			Blocks:      toBlocks(segment.Blocks),
+			ExtraBlocks: toBlocks(segment.ExtraBlocks),
+			LatestSeals: make(map[Identifier]Identifier, len(segment.LatestSeals)),
+			FirstSeal:   segment.FirstSeal.toBlockSeal(),
+		}
+		for blockID, sealID := range segment.LatestSeals {
+			snapshot.SealingSegment.LatestSeals[HexToID(blockID)] = BytesToID(sealID)
+		}
+
+		blocks := snapshot.SealingSegment.Blocks
+		recoverBlockIDs(snapshot.SealingSegment.ExtraBlocks, blocks)
+		recoverBlockIDs(blocks, nil)
+
+		// the last block of the segment is the head
+		if n := len(blocks); n > 0 && snapshot.Head != nil && blocks[n-1].Height == snapshot.Head.Height {
+			blocks[n-1].ID = snapshot.Head.ID
+		}
+	}
+
+	return snapshot, nil
+}
+
+// recoverBlockIDs sets the ID of each block of a chain from the parent ID of the next block.
+func recoverBlockIDs(blocks []*Block, next []*Block) {
+	chain := append(append([]*Block{}, blocks...), next...)
+	for i := 0; i < len(blocks); i++ {
+		if i+1 < len(chain) {
+			blocks[i].ID = chain[i+1].ParentID
+		}
+	}
+}
+
+// The types below mirror the JSON representation of the protocol state snapshot.
+
+type encodableSnapshot struct {
+	Head              *encodableHeader
+	Identities        []encodableIdentity
+	LatestSeal        *encodableSeal
+	SealingSegment    *encodableSealingSegment
+	QuorumCertificate *encodableQC
+	Phase             EpochPhase
+	Epochs            struct {
+		Previous *encodableEpoch
+		Current  *encodableEpoch
+		Next     *encodableEpoch
+	}
+	Params struct {
+		ChainID              ChainID
+		SporkID              hexBytes
+		SporkRootBlockHeight uint64
+		ProtocolVersion      uint
+	}
+}
+
+type encodableHeader struct {
+	ChainID            ChainID
+	ParentID           hexBytes
+	Height             uint64
+	PayloadHash        hexBytes
+	Timestamp          time.Time
+	View               uint64
+	ParentView         uint64
+	ParentVoterIndices []byte
+	ParentVoterIDs     []hexBytes
+	ParentVoterSigData []byte
+	ProposerID         hexBytes
+	ProposerSigData    []byte
+}
+
+func (h *encodableHeader) toBlockHeader() *BlockHeader {
+	return &BlockHeader{
+		ParentID:           BytesToID(h.ParentID),
+		Height:             h.Height,
+		Timestamp:          h.Timestamp,
+		PayloadHash:        BytesToID(h.PayloadHash),
+		View:               h.View,
+		ParentView:         h.ParentView,
+		ParentVoterIndices: h.ParentVoterIndices,
+		ParentVoterIDs:     hexBytesToIDs(h.ParentVoterIDs),
+		ParentVoterSigData: h.ParentVoterSigData,
+		ProposerID:         BytesToID(h.ProposerID),
+		ProposerSigData:    h.ProposerSigData,
+		ChainID:            h.ChainID,
+	}
+}
+
+type encodableGuarantee struct {
+	CollectionID     hexBytes
+	ReferenceBlockID hexBytes
+	SignerIndices    []byte
+	Signature        []byte
+}
+
+type encodableSeal struct {
+	BlockID                hexBytes
+	ResultID               hexBytes
+	FinalState             hexBytes
+	AggregatedApprovalSigs []struct {
+		VerifierSignatures [][]byte
+		SignerIDs          []hexBytes
+	}
+}
+
+func (s *encodableSeal) toBlockSeal() *BlockSeal {
+	if s == nil {
+		return nil
+	}
+
+	sigs := make([]*AggregatedSignature, len(s.AggregatedApprovalSigs))
+	for i, sig := range s.AggregatedApprovalSigs {
+		sigs[i] = &AggregatedSignature{
+			VerifierSignatures: sig.VerifierSignatures,
+			SignerIDs:          hexBytesToIDs(sig.SignerIDs),
+		}
+	}
+
+	return &BlockSeal{
+		BlockID:                BytesToID(s.BlockID),
+		ResultID:               BytesToID(s.ResultID),
+		FinalState:             StateCommitment(BytesToID(s.FinalState)),
+		AggregatedApprovalSigs: sigs,
+	}
+}
+
+type encodableBlock struct {
+	Header  *encodableHeader
+	Payload *struct {
+		Guarantees []*encodableGuarantee
+		Seals      []*encodableSeal
+	}
+}
+
+func toBlocks(l []*encodableBlock) []*Block {
+	blocks := make([]*Block, 0, len(l))
+	for _, b := range l {
+		if b == nil || b.Header == nil {
+			continue
+		}
+
+		block := &Block{BlockHeader: *b.Header.toBlockHeader()}
+		if b.Payload != nil {
+			for _, g := range b.Payload.Guarantees {
+				block.CollectionGuarantees = append(block.CollectionGuarantees, &CollectionGuarantee{
+					CollectionID:     BytesToID(g.CollectionID),
+					ReferenceBlockID: BytesToID(g.ReferenceBlockID),
+					SignerIndices:    g.SignerIndices,
+					Signature:        g.Signature,
+				})
+			}
+			for _, s := range b.Payload.Seals {
+				block.Seals = append(block.Seals, s.toBlockSeal())
+			}
+		}
+		blocks = append(blocks, block)
+	}
+	return blocks
+}
+
+type encodableSealingSegment struct {
+	Blocks      []*encodableBlock
+	ExtraBlocks []*encodableBlock
+	LatestSeals map[string]hexBytes
+	FirstSeal   *encodableSeal
+}
+
+type encodableQC struct {
+	View          uint64
+	BlockID       hexBytes
+	SignerIndices []byte
+	SigData       []byte
+}
+
+type encodableEpoch struct {
+	Counter            uint64
+	FirstView          uint64
+	DKGPhase1FinalView uint64
+	DKGPhase2FinalView uint64
+	DKGPhase3FinalView uint64
+	FinalView          uint64
+	RandomSource       []byte
+	InitialIdentities  []encodableIdentity
+	Clustering         [][]encodableIdentity
+	DKG                *struct {
+		GroupKey     hexBytes
+		Participants map[string]struct {
+			Index    uint
+			KeyShare hexBytes
+		}
+	}
+	FirstHeight *uint64
+	FinalHeight *uint64
+}
+
+func (e *encodableEpoch) toEpoch() *Epoch {
+	if e == nil {
+		return nil
+	}
+
+	clustering := make([][]Identifier, len(e.Clustering))
+	for i, cluster := range e.Clustering {
+		members := toIdentities(cluster)
+		clustering[i] = make([]Identifier, len(members))
+		for j, member := range members {
+			clustering[i][j] = member.NodeID
+		}
+	}
+
+	epoch := &Epoch{
+		Counter:            e.Counter,
+		FirstView:          e.FirstView,
+		FinalView:          e.FinalView,
+		DKGPhase1FinalView: e.DKGPhase1FinalView,
+		DKGPhase2FinalView: e.DKGPhase2FinalView,
+		DKGPhase3FinalView: e.DKGPhase3FinalView,
+		RandomSource:       e.RandomSource,
+		InitialIdentities:  toIdentities(e.InitialIdentities),
+		Clustering:         clustering,
+		FirstHeight:        e.FirstHeight,
+		FinalHeight:        e.FinalHeight,
+	}
+
+	if e.DKG != nil {
+		epoch.DKGGroupKey = e.DKG.GroupKey
+		epoch.DKGParticipants = make(map[Identifier]DKGParticipant, len(e.DKG.Participants))
+		for nodeID, p := range e.DKG.Participants {
+			epoch.DKGParticipants[HexToID(nodeID)] = DKGParticipant{
+				Index:    p.Index,
+				KeyShare: p.KeyShare,
+			}
+		}
+	}
+
+	return epoch
+}
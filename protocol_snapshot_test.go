@@ -0,0 +1,104 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2023 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeProtocolStateSnapshot(t *testing.T) {
+	const (
+		headID   = "0303030303030303030303030303030303030303030303030303030303030303"
+		parentID = "0404040404040404040404040404040404040404040404040404040404040404"
+	)
+
+	identity := `{"NodeID": "` + testNodeID + `", "Address": "collection-1:3569", "Role": "collection", "Weight": 100}`
+
+	payload := []byte(`{
+		"Head": {"ChainID": "flow-mainnet", "ParentID": "` + parentID + `", "Height": 11, "View": 20, "Timestamp": "2023-01-02T03:04:05Z"},
+		"Identities": [` + identity + `],
+		"SealingSegment": {
+			"Blocks": [
+				{"Header": {"ParentID": "` + testNodeID2 + `", "Height": 10, "View": 19}, "Payload": {"Guarantees": [{"CollectionID": "` + testNodeID + `"}], "Seals": []}},
+				{"Header": {"ParentID": "` + parentID + `", "Height": 11, "View": 20}, "Payload": {"Guarantees": [], "Seals": []}}
+			],
+			"LatestSeals": {"` + headID + `": "` + testNodeID2 + `"}
+		},
+		"QuorumCertificate": {"View": 20, "BlockID": "` + headID + `", "SigData": "AQ=="},
+		"Phase": 2,
+		"Epochs": {
+			"Current": {
+				"Counter": 5,
+				"FirstView": 10,
+				"FinalView": 100,
+				"InitialIdentities": [` + identity + `],
+				"Clustering": [[` + identity + `]],
+				"DKG": {"GroupKey": "0a0b", "Participants": {"` + testNodeID2 + `": {"Index": 0, "KeyShare": "0c"}}},
+				"FirstHeight": 3
+			},
+			"Next": null
+		},
+		"Params": {"ChainID": "flow-mainnet", "SporkID": "` + testNodeID + `", "SporkRootBlockHeight": 1, "ProtocolVersion": 2}
+	}`)
+
+	snapshot, err := DecodeProtocolStateSnapshot(payload)
+	require.NoError(t, err)
+
+	require.NotNil(t, snapshot.Head)
+	assert.Equal(t, HexToID(headID), snapshot.Head.ID)
+	assert.Equal(t, uint64(11), snapshot.Head.Height)
+	assert.Equal(t, Mainnet, snapshot.Head.ChainID)
+
+	require.Len(t, snapshot.Identities, 1)
+	assert.Equal(t, NodeRoleCollection, snapshot.Identities[0].Role)
+	assert.Equal(t, uint64(100), snapshot.Identities[0].Weight)
+
+	blocks := snapshot.SealingSegment.Blocks
+	require.Len(t, blocks, 2)
+	assert.Equal(t, HexToID(parentID), blocks[0].ID)
+	assert.Equal(t, HexToID(headID), blocks[1].ID)
+	require.Len(t, blocks[0].CollectionGuarantees, 1)
+	assert.Equal(t, HexToID(testNodeID), blocks[0].CollectionGuarantees[0].CollectionID)
+	assert.Equal(t, HexToID(testNodeID2), snapshot.SealingSegment.LatestSeals[HexToID(headID)])
+
+	assert.Equal(t, EpochPhaseSetup, snapshot.Phase)
+	require.NotNil(t, snapshot.Epochs.Current)
+	assert.Nil(t, snapshot.Epochs.Next)
+	assert.Nil(t, snapshot.Epochs.Previous)
+
+	current := snapshot.Epochs.Current
+	assert.Equal(t, uint64(5), current.Counter)
+	assert.Equal(t, [][]Identifier{{HexToID(testNodeID)}}, current.Clustering)
+	assert.Equal(t, []byte{0xa, 0xb}, current.DKGGroupKey)
+	assert.Equal(t, DKGParticipant{Index: 0, KeyShare: []byte{0xc}}, current.DKGParticipants[HexToID(testNodeID2)])
+	require.NotNil(t, current.FirstHeight)
+	assert.Equal(t, uint64(3), *current.FirstHeight)
+	assert.Nil(t, current.FinalHeight)
+
+	assert.Equal(t, HexToID(testNodeID), snapshot.Params.SporkID)
+	assert.Equal(t, uint(2), snapshot.Params.ProtocolVersion)
+}
+
+func TestDecodeProtocolStateSnapshot_Invalid(t *testing.T) {
+	_, err := DecodeProtocolStateSnapshot([]byte("not json"))
+	assert.Error(t, err)
+}
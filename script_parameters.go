@@ -0,0 +1,228 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2023 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flow
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/onflow/cadence"
+	jsoncdc "github.com/onflow/cadence/encoding/json"
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/parser"
+)
+
+// ErrNoEntryPoint is returned when a script has neither a transaction declaration nor a main function.
+var ErrNoEntryPoint = errors.New("script has no transaction declaration or main function")
+
+// A ScriptParameter is a parameter declared by a transaction or a script.
+type ScriptParameter struct {
+	// Name is the name of the parameter.
+	Name string
+	// Type is the declared Cadence type of the parameter, e.g. "UFix64" or "{String: [Address]}".
+	Type string
+
+	astType ast.Type
+}
+
+// ArgumentCountError indicates that the number of arguments does not match the number of parameters.
+type ArgumentCountError struct {
+	Expected int
+	Actual   int
+}
+
+func (e ArgumentCountError) Error() string {
+	return fmt.Sprintf("expected %d arguments, got %d", e.Expected, e.Actual)
+}
+
+// ArgumentTypeError indicates that an argument does not have the type of its parameter.
+type ArgumentTypeError struct {
+	Index     int
+	Parameter ScriptParameter
+	Value     cadence.Value
+}
+
+func (e ArgumentTypeError) Error() string {
+	return fmt.Sprintf("argument %d (%s) must be of type %s, got %s", e.Index, e.Parameter.Name, e.Parameter.Type, e.Value)
+}
+
+// ParseScriptParameters returns the parameters declared by a transaction or a script.
+//
+// The parameters of the transaction declaration are returned for transactions, and the
+// parameters of the main function for scripts.
+func ParseScriptParameters(script []byte) ([]ScriptParameter, error) {
+	program, err := parser.ParseProgram(nil, script, parser.Config{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse script: %w", err)
+	}
+
+	var parameterList *ast.ParameterList
+	if transactions := program.TransactionDeclarations(); len(transactions) > 0 {
+		parameterList = transactions[0].ParameterList
+	} else {
+		for _, function := range program.FunctionDeclarations() {
+			if function.Identifier.Identifier == "main" {
+				parameterList = function.ParameterList
+				break
+			}
+		}
+		if parameterList == nil {
+			return nil, ErrNoEntryPoint
+		}
+	}
+
+	if parameterList == nil {
+		return nil, nil
+	}
+
+	parameters := make([]ScriptParameter, len(parameterList.Parameters))
+	for i, p := range parameterList.Parameters {
+		parameters[i] = ScriptParameter{
+			Name:    p.Identifier.Identifier,
+			Type:    p.TypeAnnotation.Type.String(),
+			astType: p.TypeAnnotation.Type,
+		}
+	}
+
+	return parameters, nil
+}
+
+// DecodeScriptArguments decodes the JSON-CDC encoded arguments of a transaction or script,
+// and returns them by parameter name.
+//
+// An ArgumentCountError is returned if the number of arguments does not match the declared
+// parameters, and an ArgumentTypeError if an argument does not match the type of its parameter.
+// Composite and interface types are not checked, as that requires the imported contracts.
+func DecodeScriptArguments(script []byte, arguments [][]byte) (map[string]cadence.Value, error) {
+	parameters, err := ParseScriptParameters(script)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(parameters) != len(arguments) {
+		return nil, ArgumentCountError{Expected: len(parameters), Actual: len(arguments)}
+	}
+
+	values := make(map[string]cadence.Value, len(arguments))
+	for i, arg := range arguments {
+		value, err := jsoncdc.Decode(nil, arg)
+		if err != nil {
+			return nil, InvalidArgumentError{Index: i, Err: err}
+		}
+
+		if !valueHasType(value, parameters[i].astType) {
+			return nil, ArgumentTypeError{Index: i, Parameter: parameters[i], Value: value}
+		}
+
+		values[parameters[i].Name] = value
+	}
+
+	return values, nil
+}
+
+// Parameters returns the parameters declared by the transaction script.
+func (t *Transaction) Parameters() ([]ScriptParameter, error) {
+	return ParseScriptParameters(t.Script)
+}
+
+// DecodeArguments decodes the transaction arguments against the parameters declared by
+// the transaction script, and returns them by parameter name.
+//
+// See DecodeScriptArguments for the errors returned on mismatches.
+func (t *Transaction) DecodeArguments() (map[string]cadence.Value, error) {
+	return DecodeScriptArguments(t.Script, t.Arguments)
+}
+
+// primitiveTypes are the types whose values can be checked by their type ID.
+var primitiveTypes = map[string]struct{}{
+	"Bool": {}, "String": {}, "Character": {}, "Address": {}, "Void": {},
+	"Int": {}, "Int8": {}, "Int16": {}, "Int32": {}, "Int64": {}, "Int128": {}, "Int256": {},
+	"UInt": {}, "UInt8": {}, "UInt16": {}, "UInt32": {}, "UInt64": {}, "UInt128": {}, "UInt256": {},
+	"Word8": {}, "Word16": {}, "Word32": {}, "Word64": {},
+	"Fix64": {}, "UFix64": {},
+	"StoragePath": {}, "PublicPath": {}, "PrivatePath": {},
+}
+
+// valueHasType returns false if the value is known not to be of the given type.
+//
+// Types which cannot be checked without type information of imported contracts,
+// like composites, or which are abstract, like AnyStruct, are always accepted.
+func valueHasType(value cadence.Value, t ast.Type) bool {
+	switch t := t.(type) {
+	case *ast.NominalType:
+		if len(t.NestedIdentifiers) > 0 {
+			return true
+		}
+		if _, ok := primitiveTypes[t.Identifier.Identifier]; !ok {
+			return true
+		}
+		return value.Type() != nil && value.Type().ID() == t.Identifier.Identifier
+
+	case *ast.OptionalType:
+		optional, ok := value.(cadence.Optional)
+		if !ok {
+			// a value of type T is also a value of type T?
+			return valueHasType(value, t.Type)
+		}
+		return optional.Value == nil || valueHasType(optional.Value, t.Type)
+
+	case *ast.VariableSizedType:
+		array, ok := value.(cadence.Array)
+		if !ok {
+			return false
+		}
+		return allHaveType(array.Values, t.Type)
+
+	case *ast.ConstantSizedType:
+		array, ok := value.(cadence.Array)
+		if !ok {
+			return false
+		}
+		if t.Size != nil && t.Size.Value != nil {
+			if !t.Size.Value.IsInt64() || int64(len(array.Values)) != t.Size.Value.Int64() {
+				return false
+			}
+		}
+		return allHaveType(array.Values, t.Type)
+
+	case *ast.DictionaryType:
+		dictionary, ok := value.(cadence.Dictionary)
+		if !ok {
+			return false
+		}
+		for _, pair := range dictionary.Pairs {
+			if !valueHasType(pair.Key, t.KeyType) || !valueHasType(pair.Value, t.ValueType) {
+				return false
+			}
+		}
+		return true
+
+	default:
+		return true
+	}
+}
+
+func allHaveType(values []cadence.Value, t ast.Type) bool {
+	for _, v := range values {
+		if !valueHasType(v, t) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,135 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2023 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flow
+
+import (
+	"testing"
+
+	"github.com/onflow/cadence"
+	jsoncdc "github.com/onflow/cadence/encoding/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const parametersTestTransaction = `
+import FungibleToken from 0xee82856bf20e2aa6
+
+transaction(amount: UFix64, to: Address, memo: String?, tags: {String: [Int]}, pair: [UInt8; 2]) {
+	prepare(signer: AuthAccount) {}
+}
+`
+
+func encodeArguments(t *testing.T, values ...cadence.Value) [][]byte {
+	args := make([][]byte, len(values))
+	for i, v := range values {
+		b, err := jsoncdc.Encode(v)
+		require.NoError(t, err)
+		args[i] = b
+	}
+	return args
+}
+
+func TestParseScriptParameters(t *testing.T) {
+	t.Run("transaction", func(t *testing.T) {
+		parameters, err := ParseScriptParameters([]byte(parametersTestTransaction))
+		require.NoError(t, err)
+
+		names := make([]string, len(parameters))
+		types := make([]string, len(parameters))
+		for i, p := range parameters {
+			names[i] = p.Name
+			types[i] = p.Type
+		}
+		assert.Equal(t, []string{"amount", "to", "memo", "tags", "pair"}, names)
+		assert.Equal(t, []string{"UFix64", "Address", "String?", "{String: [Int]}", "[UInt8; 2]"}, types)
+	})
+
+	t.Run("script", func(t *testing.T) {
+		parameters, err := ParseScriptParameters([]byte(`pub fun main(account: Address): UFix64 { return 1.0 }`))
+		require.NoError(t, err)
+		require.Len(t, parameters, 1)
+		assert.Equal(t, "account", parameters[0].Name)
+	})
+
+	t.Run("no parameters", func(t *testing.T) {
+		parameters, err := ParseScriptParameters([]byte(`transaction { execute {} }`))
+		require.NoError(t, err)
+		assert.Empty(t, parameters)
+	})
+
+	t.Run("no entry point", func(t *testing.T) {
+		_, err := ParseScriptParameters([]byte(`pub fun helper() {}`))
+		assert.ErrorIs(t, err, ErrNoEntryPoint)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := ParseScriptParameters([]byte(`transaction(`))
+		assert.Error(t, err)
+	})
+}
+
+func TestTransaction_DecodeArguments(t *testing.T) {
+	amount, err := cadence.NewUFix64("1.5")
+	require.NoError(t, err)
+	to := cadence.NewAddress(HexToAddress("01"))
+	tags := cadence.NewDictionary([]cadence.KeyValuePair{
+		{Key: cadence.String("a"), Value: cadence.NewArray([]cadence.Value{cadence.NewInt(1)})},
+	})
+	pair := cadence.NewArray([]cadence.Value{cadence.NewUInt8(1), cadence.NewUInt8(2)})
+
+	newTx := func(values ...cadence.Value) *Transaction {
+		tx := NewTransaction().SetScript([]byte(parametersTestTransaction))
+		tx.Arguments = encodeArguments(t, values...)
+		return tx
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		tx := newTx(amount, to, cadence.NewOptional(nil), tags, pair)
+
+		values, err := tx.DecodeArguments()
+		require.NoError(t, err)
+		assert.Equal(t, amount, values["amount"])
+		assert.Equal(t, to, values["to"])
+		assert.Len(t, values, 5)
+	})
+
+	t.Run("arity", func(t *testing.T) {
+		tx := newTx(amount, to)
+
+		_, err := tx.DecodeArguments()
+		assert.Equal(t, ArgumentCountError{Expected: 5, Actual: 2}, err)
+	})
+
+	t.Run("type mismatch", func(t *testing.T) {
+		tests := map[string][]cadence.Value{
+			"amount": {cadence.NewInt(1), to, cadence.String("memo"), tags, pair},
+			"memo":   {amount, to, cadence.NewOptional(cadence.NewInt(1)), tags, pair},
+			"tags":   {amount, to, cadence.String("memo"), cadence.NewArray(nil), pair},
+			"pair":   {amount, to, cadence.String("memo"), tags, cadence.NewArray([]cadence.Value{cadence.NewUInt8(1)})},
+		}
+
+		for name, values := range tests {
+			_, err := newTx(values...).DecodeArguments()
+
+			var typeErr ArgumentTypeError
+			require.ErrorAs(t, err, &typeErr, name)
+			assert.Equal(t, name, typeErr.Parameter.Name)
+		}
+	})
+}
@@ -0,0 +1,272 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2023 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flow
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+)
+
+// List of the service event types emitted by the protocol.
+const (
+	ServiceEventEpochSetup    string = "setup"
+	ServiceEventEpochCommit   string = "commit"
+	ServiceEventVersionBeacon string = "version-beacon"
+)
+
+// NodeRole is the role of a node in the network.
+type NodeRole string
+
+const (
+	NodeRoleCollection   NodeRole = "collection"
+	NodeRoleConsensus    NodeRole = "consensus"
+	NodeRoleExecution    NodeRole = "execution"
+	NodeRoleVerification NodeRole = "verification"
+	NodeRoleAccess       NodeRole = "access"
+)
+
+// Identity is a node participating in an epoch.
+type Identity struct {
+	NodeID        Identifier
+	Address       string
+	Role          NodeRole
+	Weight        uint64
+	StakingPubKey []byte
+	NetworkPubKey []byte
+}
+
+// EpochSetup is the service event emitted when the setup phase of the next epoch starts.
+type EpochSetup struct {
+	// Counter is the number of the epoch being set up.
+	Counter uint64
+	// FirstView and FinalView are the range of views of the epoch (inclusive).
+	FirstView uint64
+	FinalView uint64
+	// DKGPhase1FinalView, DKGPhase2FinalView and DKGPhase3FinalView are the final views of the DKG phases.
+	DKGPhase1FinalView uint64
+	DKGPhase2FinalView uint64
+	DKGPhase3FinalView uint64
+	// Participants are all the nodes participating in the epoch.
+	Participants []*Identity
+	// Assignments are the IDs of the collection nodes in each cluster, indexed by cluster.
+	Assignments [][]Identifier
+	// RandomSource is the source of randomness of the epoch.
+	RandomSource []byte
+}
+
+// ClusterQCVoteData is the quorum certificate of the root block of a collection cluster.
+type ClusterQCVoteData struct {
+	SigData  []byte
+	VoterIDs []Identifier
+}
+
+// EpochCommit is the service event emitted when the next epoch is committed.
+type EpochCommit struct {
+	// Counter is the number of the committed epoch.
+	Counter uint64
+	// ClusterQCs are the root quorum certificates of the clusters, ordered as the cluster assignments.
+	ClusterQCs []ClusterQCVoteData
+	// DKGGroupKey is the random beacon group public key.
+	DKGGroupKey []byte
+	// DKGParticipantKeys are the random beacon public keys of the consensus nodes, ordered as the participants.
+	DKGParticipantKeys [][]byte
+}
+
+// VersionBoundary is the node software version required from a block height on.
+type VersionBoundary struct {
+	BlockHeight uint64
+	Version     string
+}
+
+// VersionBeacon is the service event announcing the node software version boundaries.
+type VersionBeacon struct {
+	VersionBoundaries []VersionBoundary
+	Sequence          uint64
+}
+
+// An UnknownServiceEventError is returned when decoding a service event of an unknown type.
+type UnknownServiceEventError struct {
+	Type string
+}
+
+func (e UnknownServiceEventError) Error() string {
+	return fmt.Sprintf("unknown service event type: %s", e.Type)
+}
+
+// Decode decodes the payload of the service event into its typed representation.
+//
+// It returns a *EpochSetup, *EpochCommit or *VersionBeacon depending on the event type,
+// and an UnknownServiceEventError for any other type.
+func (s ServiceEvent) Decode() (interface{}, error) {
+	switch s.Type {
+	case ServiceEventEpochSetup:
+		return s.EpochSetup()
+	case ServiceEventEpochCommit:
+		return s.EpochCommit()
+	case ServiceEventVersionBeacon:
+		return s.VersionBeacon()
+	default:
+		return nil, UnknownServiceEventError{Type: s.Type}
+	}
+}
+
+// EpochSetup decodes the payload of an epoch setup service event.
+func (s ServiceEvent) EpochSetup() (*EpochSetup, error) {
+	if s.Type != ServiceEventEpochSetup {
+		return nil, fmt.Errorf("service event of type %s is not an epoch setup", s.Type)
+	}
+
+	var event struct {
+		Counter            uint64
+		FirstView          uint64
+		DKGPhase1FinalView uint64
+		DKGPhase2FinalView uint64
+		DKGPhase3FinalView uint64
+		FinalView          uint64
+		Participants       []encodableIdentity
+		Assignments        [][]hexBytes
+		RandomSource       []byte
+	}
+	if err := json.Unmarshal(s.Payload, &event); err != nil {
+		return nil, fmt.Errorf("failed to decode epoch setup: %w", err)
+	}
+
+	assignments := make([][]Identifier, len(event.Assignments))
+	for i, cluster := range event.Assignments {
+		assignments[i] = hexBytesToIDs(cluster)
+	}
+
+	return &EpochSetup{
+		Counter:            event.Counter,
+		FirstView:          event.FirstView,
+		FinalView:          event.FinalView,
+		DKGPhase1FinalView: event.DKGPhase1FinalView,
+		DKGPhase2FinalView: event.DKGPhase2FinalView,
+		DKGPhase3FinalView: event.DKGPhase3FinalView,
+		Participants:       toIdentities(event.Participants),
+		Assignments:        assignments,
+		RandomSource:       event.RandomSource,
+	}, nil
+}
+
+// EpochCommit decodes the payload of an epoch commit service event.
+func (s ServiceEvent) EpochCommit() (*EpochCommit, error) {
+	if s.Type != ServiceEventEpochCommit {
+		return nil, fmt.Errorf("service event of type %s is not an epoch commit", s.Type)
+	}
+
+	var event struct {
+		Counter    uint64
+		ClusterQCs []struct {
+			SigData  []byte
+			VoterIDs []hexBytes
+		}
+		DKGGroupKey        hexBytes
+		DKGParticipantKeys []hexBytes
+	}
+	if err := json.Unmarshal(s.Payload, &event); err != nil {
+		return nil, fmt.Errorf("failed to decode epoch commit: %w", err)
+	}
+
+	qcs := make([]ClusterQCVoteData, len(event.ClusterQCs))
+	for i, qc := range event.ClusterQCs {
+		qcs[i] = ClusterQCVoteData{
+			SigData:  qc.SigData,
+			VoterIDs: hexBytesToIDs(qc.VoterIDs),
+		}
+	}
+
+	keys := make([][]byte, len(event.DKGParticipantKeys))
+	for i, key := range event.DKGParticipantKeys {
+		keys[i] = key
+	}
+
+	return &EpochCommit{
+		Counter:            event.Counter,
+		ClusterQCs:         qcs,
+		DKGGroupKey:        event.DKGGroupKey,
+		DKGParticipantKeys: keys,
+	}, nil
+}
+
+// VersionBeacon decodes the payload of a version beacon service event.
+func (s ServiceEvent) VersionBeacon() (*VersionBeacon, error) {
+	if s.Type != ServiceEventVersionBeacon {
+		return nil, fmt.Errorf("service event of type %s is not a version beacon", s.Type)
+	}
+
+	var event VersionBeacon
+	if err := json.Unmarshal(s.Payload, &event); err != nil {
+		return nil, fmt.Errorf("failed to decode version beacon: %w", err)
+	}
+
+	return &event, nil
+}
+
+// encodableIdentity is the JSON representation of an identity used by the protocol.
+type encodableIdentity struct {
+	NodeID        hexBytes
+	Address       string
+	Role          NodeRole
+	Weight        uint64
+	Stake         uint64 // name of the weight in older versions of the protocol
+	StakingPubKey []byte
+	NetworkPubKey []byte
+}
+
+func toIdentities(l []encodableIdentity) []*Identity {
+	identities := make([]*Identity, len(l))
+	for i, e := range l {
+		weight := e.Weight
+		if weight == 0 {
+			weight = e.Stake
+		}
+
+		identities[i] = &Identity{
+			NodeID:        BytesToID(e.NodeID),
+			Address:       e.Address,
+			Role:          e.Role,
+			Weight:        weight,
+			StakingPubKey: e.StakingPubKey,
+			NetworkPubKey: e.NetworkPubKey,
+		}
+	}
+	return identities
+}
+
+// hexBytes decodes the hex encoded JSON strings used by the protocol for identifiers and keys.
+type hexBytes []byte
+
+func (h *hexBytes) UnmarshalText(text []byte) error {
+	b, err := hex.DecodeString(string(text))
+	if err != nil {
+		return err
+	}
+	*h = b
+	return nil
+}
+
+func hexBytesToIDs(l []hexBytes) []Identifier {
+	ids := make([]Identifier, len(l))
+	for i, b := range l {
+		ids[i] = BytesToID(b)
+	}
+	return ids
+}
@@ -0,0 +1,118 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2023 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testNodeID  = "0101010101010101010101010101010101010101010101010101010101010101"
+	testNodeID2 = "0202020202020202020202020202020202020202020202020202020202020202"
+)
+
+func TestServiceEvent_EpochSetup(t *testing.T) {
+	event := ServiceEvent{
+		Type: ServiceEventEpochSetup,
+		Payload: []byte(`{
+			"Counter": 2,
+			"FirstView": 100,
+			"DKGPhase1FinalView": 150,
+			"DKGPhase2FinalView": 200,
+			"DKGPhase3FinalView": 250,
+			"FinalView": 300,
+			"Participants": [
+				{"NodeID": "` + testNodeID + `", "Address": "collection-1:3569", "Role": "collection", "Weight": 100, "StakingPubKey": "AQI=", "NetworkPubKey": "AwQ="},
+				{"NodeID": "` + testNodeID2 + `", "Address": "consensus-1:3569", "Role": "consensus", "Stake": 50}
+			],
+			"Assignments": [["` + testNodeID + `"]],
+			"RandomSource": "BQY="
+		}`),
+	}
+
+	decoded, err := event.Decode()
+	require.NoError(t, err)
+
+	setup, ok := decoded.(*EpochSetup)
+	require.True(t, ok)
+
+	assert.Equal(t, uint64(2), setup.Counter)
+	assert.Equal(t, uint64(100), setup.FirstView)
+	assert.Equal(t, uint64(300), setup.FinalView)
+	assert.Equal(t, uint64(250), setup.DKGPhase3FinalView)
+	assert.Equal(t, []byte{5, 6}, setup.RandomSource)
+
+	require.Len(t, setup.Participants, 2)
+	assert.Equal(t, &Identity{
+		NodeID:        HexToID(testNodeID),
+		Address:       "collection-1:3569",
+		Role:          NodeRoleCollection,
+		Weight:        100,
+		StakingPubKey: []byte{1, 2},
+		NetworkPubKey: []byte{3, 4},
+	}, setup.Participants[0])
+	assert.Equal(t, uint64(50), setup.Participants[1].Weight)
+
+	assert.Equal(t, [][]Identifier{{HexToID(testNodeID)}}, setup.Assignments)
+}
+
+func TestServiceEvent_EpochCommit(t *testing.T) {
+	event := ServiceEvent{
+		Type: ServiceEventEpochCommit,
+		Payload: []byte(`{
+			"Counter": 2,
+			"ClusterQCs": [{"SigData": "AQI=", "VoterIDs": ["` + testNodeID + `"]}],
+			"DKGGroupKey": "0a0b",
+			"DKGParticipantKeys": ["0c0d"]
+		}`),
+	}
+
+	commit, err := event.EpochCommit()
+	require.NoError(t, err)
+
+	assert.Equal(t, uint64(2), commit.Counter)
+	assert.Equal(t, []ClusterQCVoteData{{SigData: []byte{1, 2}, VoterIDs: []Identifier{HexToID(testNodeID)}}}, commit.ClusterQCs)
+	assert.Equal(t, []byte{0xa, 0xb}, commit.DKGGroupKey)
+	assert.Equal(t, [][]byte{{0xc, 0xd}}, commit.DKGParticipantKeys)
+}
+
+func TestServiceEvent_VersionBeacon(t *testing.T) {
+	event := ServiceEvent{
+		Type:    ServiceEventVersionBeacon,
+		Payload: []byte(`{"VersionBoundaries": [{"BlockHeight": 10, "Version": "0.31.0"}], "Sequence": 1}`),
+	}
+
+	beacon, err := event.VersionBeacon()
+	require.NoError(t, err)
+	assert.Equal(t, &VersionBeacon{
+		VersionBoundaries: []VersionBoundary{{BlockHeight: 10, Version: "0.31.0"}},
+		Sequence:          1,
+	}, beacon)
+}
+
+func TestServiceEvent_Unknown(t *testing.T) {
+	_, err := ServiceEvent{Type: "unknown"}.Decode()
+	assert.ErrorAs(t, err, &UnknownServiceEventError{})
+
+	_, err = ServiceEvent{Type: ServiceEventEpochCommit}.EpochSetup()
+	assert.Error(t, err)
+}
@@ -60,8 +60,11 @@ type Addresses struct {
 }
 
 func AddressGenerator() *Addresses {
+	// the emulator address scheme is built in
+	generator, _ := flow.NewChainAddressGenerator(flow.Emulator)
+
 	return &Addresses{
-		generator: flow.NewAddressGenerator(flow.Emulator),
+		generator: generator,
 	}
 }
 
@@ -129,10 +132,19 @@ func (g *BlockHeaders) New() flow.BlockHeader {
 	defer func() { g.count++ }()
 
 	return flow.BlockHeader{
-		ID:        g.ids.New(),
-		ParentID:  g.ids.New(),
-		Height:    uint64(g.count),
-		Timestamp: g.startTime.Add(time.Hour * time.Duration(g.count)),
+		ID:                 g.ids.New(),
+		ParentID:           g.ids.New(),
+		Height:             uint64(g.count),
+		Timestamp:          g.startTime.Add(time.Hour * time.Duration(g.count)),
+		PayloadHash:        g.ids.New(),
+		View:               uint64(g.count) + 1,
+		ParentView:         uint64(g.count),
+		ParentVoterIndices: []byte{0xff},
+		ParentVoterIDs:     []flow.Identifier{g.ids.New(), g.ids.New()},
+		ParentVoterSigData: []byte{byte(g.count)},
+		ProposerID:         g.ids.New(),
+		ProposerSigData:    []byte{byte(g.count)},
+		ChainID:            flow.Emulator,
 	}
 }
 
@@ -171,7 +183,12 @@ func CollectionGuaranteeGenerator() *CollectionGuarantees {
 
 func (g *CollectionGuarantees) New() *flow.CollectionGuarantee {
 	return &flow.CollectionGuarantee{
-		CollectionID: g.ids.New(),
+		CollectionID:     g.ids.New(),
+		ReferenceBlockID: g.ids.New(),
+		Signature:        []byte{0x1},
+		SignerIndices:    []byte{0xff},
+		SignerIDs:        []flow.Identifier{g.ids.New(), g.ids.New()},
+		Signatures:       [][]byte{{0x2}, {0x3}},
 	}
 }
 
@@ -185,6 +202,14 @@ func (g *BlockSeals) New() *flow.BlockSeal {
 	return &flow.BlockSeal{
 		BlockID:            g.ids.New(),
 		ExecutionReceiptID: g.ids.New(),
+		ResultID:           g.ids.New(),
+		FinalState:         flow.StateCommitment(g.ids.New()),
+		AggregatedApprovalSigs: []*flow.AggregatedSignature{
+			{
+				VerifierSignatures: [][]byte{{0x1}, {0x2}},
+				SignerIDs:          []flow.Identifier{g.ids.New(), g.ids.New()},
+			},
+		},
 	}
 }
 
@@ -20,6 +20,8 @@ package flow
 
 import (
 	"bytes"
+	"database/sql/driver"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"sort"
@@ -133,16 +135,48 @@ func (t *Transaction) AddArgument(arg cadence.Value) error {
 		return fmt.Errorf("failed to encode argument: %w", err)
 	}
 
-	t.Arguments = append(t.Arguments, encodedArg)
+	t.Arguments = append(t.Arguments, NormalizeArgument(encodedArg))
 	return nil
 }
 
 // AddRawArgument adds a raw JSON-CDC encoded argument to this transaction.
+//
+// The argument is stored in its canonical form, see NormalizeArgument.
 func (t *Transaction) AddRawArgument(arg []byte) *Transaction {
-	t.Arguments = append(t.Arguments, arg)
+	t.Arguments = append(t.Arguments, NormalizeArgument(arg))
 	return t
 }
 
+// NormalizeArgument returns the canonical form of a JSON-CDC encoded argument.
+//
+// The Go JSON-CDC encoder terminates its output with a newline while other SDKs do not,
+// so the canonical form omits a trailing newline. The argument is otherwise left as is,
+// as signatures produced by other SDKs cover the exact argument bytes.
+//
+// The returned slice shares the memory of the argument.
+func NormalizeArgument(arg []byte) []byte {
+	if len(arg) > 0 && arg[len(arg)-1] == '\n' {
+		return arg[:len(arg)-1]
+	}
+	return arg
+}
+
+// CanonicalArguments returns the arguments of the transaction in their canonical form.
+//
+// The canonical arguments are the ones covered by the transaction signatures and ID,
+// and must be the ones sent to the network. The transaction is not modified.
+func (t *Transaction) CanonicalArguments() [][]byte {
+	if t.Arguments == nil {
+		return nil
+	}
+
+	args := make([][]byte, len(t.Arguments))
+	for i, arg := range t.Arguments {
+		args[i] = NormalizeArgument(arg)
+	}
+	return args
+}
+
 // Argument returns the decoded argument at the given index.
 func (t *Transaction) Argument(i int, options ...jsoncdc.Option) (cadence.Value, error) {
 	if i < 0 {
@@ -322,16 +356,9 @@ func (t *Transaction) payloadCanonicalForm() payloadCanonicalForm {
 		authorizers[i] = auth.Bytes()
 	}
 
-	// note(sideninja): This is a temporary workaround until cadence defines canonical format addressing the issue https://github.com/onflow/flow-go-sdk/issues/286
-	for i, arg := range t.Arguments {
-		if arg[len(arg)-1] == byte(10) { // extra new line character
-			t.Arguments[i] = arg[:len(arg)-1]
-		}
-	}
-
 	return payloadCanonicalForm{
 		Script:                    t.Script,
-		Arguments:                 t.Arguments,
+		Arguments:                 t.CanonicalArguments(),
 		ReferenceBlockID:          t.ReferenceBlockID[:],
 		GasLimit:                  t.GasLimit,
 		ProposalKeyAddress:        t.ProposalKey.Address.Bytes(),
@@ -357,6 +384,12 @@ func (t *Transaction) envelopeCanonicalForm() envelopeCanonicalForm {
 	}
 }
 
+// ID returns the canonical SHA3-256 hash of this transaction, which is the hash of its
+// full encoding including the signatures.
+func (t *Transaction) ID() Identifier {
+	return HashToID(hashSHA3(t.Encode()))
+}
+
 // Encode serializes the full transaction data including the payload and all signatures.
 func (t *Transaction) Encode() []byte {
 	temp := struct {
@@ -588,7 +621,87 @@ const (
 	TransactionStatusExpired
 )
 
+var transactionStatusNames = [...]string{"UNKNOWN", "PENDING", "FINALIZED", "EXECUTED", "SEALED", "EXPIRED"}
+
 // String returns the string representation of a transaction status.
 func (s TransactionStatus) String() string {
-	return [...]string{"UNKNOWN", "PENDING", "FINALIZED", "EXECUTED", "SEALED", "EXPIRED"}[s]
+	if s < 0 || int(s) >= len(transactionStatusNames) {
+		return fmt.Sprintf("TransactionStatus(%d)", int(s))
+	}
+	return transactionStatusNames[s]
+}
+
+// ParseTransactionStatus parses the string representation of a transaction status.
+func ParseTransactionStatus(str string) (TransactionStatus, error) {
+	for i, name := range transactionStatusNames {
+		if name == str {
+			return TransactionStatus(i), nil
+		}
+	}
+	return TransactionStatusUnknown, fmt.Errorf("invalid transaction status %q", str)
+}
+
+// MarshalText encodes the transaction status as its string representation.
+func (s TransactionStatus) MarshalText() ([]byte, error) {
+	if s < 0 || int(s) >= len(transactionStatusNames) {
+		return nil, fmt.Errorf("invalid transaction status %d", int(s))
+	}
+	return []byte(transactionStatusNames[s]), nil
+}
+
+// UnmarshalText decodes the transaction status as ParseTransactionStatus does.
+func (s *TransactionStatus) UnmarshalText(text []byte) error {
+	status, err := ParseTransactionStatus(string(text))
+	if err != nil {
+		return err
+	}
+	*s = status
+	return nil
+}
+
+// MarshalJSON encodes the transaction status as a JSON string.
+func (s TransactionStatus) MarshalJSON() ([]byte, error) {
+	return marshalJSONText(s)
+}
+
+// UnmarshalJSON decodes a JSON string, or the JSON number of the status. A JSON null is ignored.
+func (s *TransactionStatus) UnmarshalJSON(data []byte) error {
+	if string(data) != "null" {
+		var n int
+		if err := json.Unmarshal(data, &n); err == nil {
+			return s.Scan(int64(n))
+		}
+	}
+	return unmarshalJSONText(data, s)
+}
+
+// Value implements driver.Valuer, the transaction status is stored as its string representation.
+func (s TransactionStatus) Value() (driver.Value, error) {
+	text, err := s.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return string(text), nil
+}
+
+// Scan implements sql.Scanner. It accepts the string representation of the status or
+// its integer value, and stores TransactionStatusUnknown for NULL.
+func (s *TransactionStatus) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*s = TransactionStatusUnknown
+		return nil
+	case int64:
+		if v < 0 || v >= int64(len(transactionStatusNames)) {
+			return fmt.Errorf("invalid transaction status %d", v)
+		}
+		*s = TransactionStatus(v)
+		return nil
+	case []byte:
+		return s.UnmarshalText(v)
+	case string:
+		return s.UnmarshalText([]byte(v))
+	default:
+		return fmt.Errorf("cannot scan %T into a transaction status", src)
+	}
 }
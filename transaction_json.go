@@ -0,0 +1,330 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2023 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flow
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	jsoncdc "github.com/onflow/cadence/encoding/json"
+)
+
+// transactionJSON is the JSON representation of a transaction, matching the shape
+// used by the Access REST API and the Flow CLI.
+type transactionJSON struct {
+	ID                 string                     `json:"id,omitempty"`
+	Script             string                     `json:"script"`
+	Arguments          []transactionArgumentJSON  `json:"arguments"`
+	ReferenceBlockID   string                     `json:"reference_block_id"`
+	GasLimit           jsonUint                   `json:"gas_limit"`
+	Payer              string                     `json:"payer"`
+	ProposalKey        proposalKeyJSON            `json:"proposal_key"`
+	Authorizers        []string                   `json:"authorizers"`
+	PayloadSignatures  []transactionSignatureJSON `json:"payload_signatures"`
+	EnvelopeSignatures []transactionSignatureJSON `json:"envelope_signatures"`
+}
+
+type proposalKeyJSON struct {
+	Address        string   `json:"address"`
+	KeyIndex       jsonUint `json:"key_index"`
+	SequenceNumber jsonUint `json:"sequence_number"`
+}
+
+type transactionSignatureJSON struct {
+	Address   string   `json:"address"`
+	KeyIndex  jsonUint `json:"key_index"`
+	Signature string   `json:"signature"`
+}
+
+// transactionArgumentJSON is a JSON-CDC encoded argument.
+//
+// Arguments are encoded as base64 strings, as done by the REST API, but plain
+// JSON-CDC objects are accepted when decoding.
+type transactionArgumentJSON []byte
+
+func (a transactionArgumentJSON) MarshalJSON() ([]byte, error) {
+	return json.Marshal(base64.StdEncoding.EncodeToString(a))
+}
+
+func (a *transactionArgumentJSON) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] == '{' {
+		*a = append((*a)[:0], data...)
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("argument must be a base64 string or a JSON-CDC object: %w", err)
+	}
+
+	b, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return fmt.Errorf("failed to decode base64 argument: %w", err)
+	}
+
+	*a = b
+	return nil
+}
+
+// jsonUint is an unsigned integer encoded as a decimal string, as done by the REST API.
+//
+// Plain JSON numbers are accepted when decoding.
+type jsonUint uint64
+
+func (u jsonUint) MarshalJSON() ([]byte, error) {
+	return json.Marshal(strconv.FormatUint(uint64(u), 10))
+}
+
+func (u *jsonUint) UnmarshalJSON(data []byte) error {
+	s := strings.Trim(string(data), "\"")
+	v, err := strconv.ParseUint(s, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid unsigned integer %s: %w", data, err)
+	}
+
+	*u = jsonUint(v)
+	return nil
+}
+
+// MarshalJSON encodes the transaction using the JSON format of the Access REST API.
+//
+// The script and signatures are base64 encoded, arguments are base64 encoded JSON-CDC values
+// and addresses and identifiers are hex encoded.
+func (t Transaction) MarshalJSON() ([]byte, error) {
+	authorizers := make([]string, len(t.Authorizers))
+	for i, auth := range t.Authorizers {
+		authorizers[i] = auth.Hex()
+	}
+
+	arguments := make([]transactionArgumentJSON, len(t.Arguments))
+	for i, arg := range t.CanonicalArguments() {
+		arguments[i] = arg
+	}
+
+	return json.Marshal(transactionJSON{
+		ID:               t.ID().Hex(),
+		Script:           base64.StdEncoding.EncodeToString(t.Script),
+		Arguments:        arguments,
+		ReferenceBlockID: t.ReferenceBlockID.Hex(),
+		GasLimit:         jsonUint(t.GasLimit),
+		Payer:            t.Payer.Hex(),
+		ProposalKey: proposalKeyJSON{
+			Address:        t.ProposalKey.Address.Hex(),
+			KeyIndex:       jsonUint(t.ProposalKey.KeyIndex),
+			SequenceNumber: jsonUint(t.ProposalKey.SequenceNumber),
+		},
+		Authorizers:        authorizers,
+		PayloadSignatures:  signaturesToJSON(t.PayloadSignatures),
+		EnvelopeSignatures: signaturesToJSON(t.EnvelopeSignatures),
+	})
+}
+
+// UnmarshalJSON decodes a transaction from the JSON format of the Access REST API.
+//
+// The signer indices of the signatures are recomputed from the transaction signers.
+func (t *Transaction) UnmarshalJSON(data []byte) error {
+	var temp transactionJSON
+	if err := json.Unmarshal(data, &temp); err != nil {
+		return err
+	}
+
+	script, err := base64.StdEncoding.DecodeString(temp.Script)
+	if err != nil {
+		return fmt.Errorf("failed to decode script: %w", err)
+	}
+
+	referenceBlockID, err := identifierFromJSON(temp.ReferenceBlockID)
+	if err != nil {
+		return fmt.Errorf("invalid reference block ID: %w", err)
+	}
+
+	proposer, err := addressFromJSON(temp.ProposalKey.Address)
+	if err != nil {
+		return fmt.Errorf("invalid proposal key: %w", err)
+	}
+
+	payer, err := addressFromJSON(temp.Payer)
+	if err != nil {
+		return fmt.Errorf("invalid payer: %w", err)
+	}
+
+	payloadSignatures, err := signaturesFromJSON(temp.PayloadSignatures)
+	if err != nil {
+		return fmt.Errorf("invalid payload signatures: %w", err)
+	}
+
+	envelopeSignatures, err := signaturesFromJSON(temp.EnvelopeSignatures)
+	if err != nil {
+		return fmt.Errorf("invalid envelope signatures: %w", err)
+	}
+
+	var arguments [][]byte
+	for _, arg := range temp.Arguments {
+		arguments = append(arguments, arg)
+	}
+
+	var authorizers []Address
+	for i, auth := range temp.Authorizers {
+		authorizer, err := addressFromJSON(auth)
+		if err != nil {
+			return fmt.Errorf("invalid authorizer at index %d: %w", i, err)
+		}
+		authorizers = append(authorizers, authorizer)
+	}
+
+	*t = Transaction{
+		Script:           script,
+		Arguments:        arguments,
+		ReferenceBlockID: referenceBlockID,
+		GasLimit:         uint64(temp.GasLimit),
+		ProposalKey: ProposalKey{
+			Address:        proposer,
+			KeyIndex:       int(temp.ProposalKey.KeyIndex),
+			SequenceNumber: uint64(temp.ProposalKey.SequenceNumber),
+		},
+		Payer:              payer,
+		Authorizers:        authorizers,
+		PayloadSignatures:  payloadSignatures,
+		EnvelopeSignatures: envelopeSignatures,
+	}
+
+	if len(t.Script) == 0 {
+		t.Script = nil
+	}
+
+	t.refreshSignerIndex()
+	return nil
+}
+
+func signaturesToJSON(signatures []TransactionSignature) []transactionSignatureJSON {
+	sigs := make([]transactionSignatureJSON, len(signatures))
+	for i, sig := range signatures {
+		sigs[i] = transactionSignatureJSON{
+			Address:   sig.Address.Hex(),
+			KeyIndex:  jsonUint(sig.KeyIndex),
+			Signature: base64.StdEncoding.EncodeToString(sig.Signature),
+		}
+	}
+	return sigs
+}
+
+func signaturesFromJSON(signatures []transactionSignatureJSON) ([]TransactionSignature, error) {
+	if len(signatures) == 0 {
+		return nil, nil
+	}
+
+	sigs := make([]TransactionSignature, len(signatures))
+	for i, sig := range signatures {
+		signature, err := base64.StdEncoding.DecodeString(sig.Signature)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode signature at index %d: %w", i, err)
+		}
+
+		address, err := addressFromJSON(sig.Address)
+		if err != nil {
+			return nil, fmt.Errorf("invalid signer address at index %d: %w", i, err)
+		}
+
+		sigs[i] = TransactionSignature{
+			Address:   address,
+			KeyIndex:  int(sig.KeyIndex),
+			Signature: signature,
+		}
+	}
+	return sigs, nil
+}
+
+// addressFromJSON decodes an address as Address.UnmarshalText does.
+func addressFromJSON(h string) (Address, error) {
+	var a Address
+	err := a.UnmarshalText([]byte(h))
+	return a, err
+}
+
+// identifierFromJSON decodes an identifier as ParseIdentifier does, an empty string being the empty identifier.
+func identifierFromJSON(h string) (Identifier, error) {
+	if h == "" {
+		return EmptyID, nil
+	}
+	return ParseIdentifier(h)
+}
+
+// PrettyString returns a human-readable, multi-line description of the transaction.
+//
+// Arguments are decoded from JSON-CDC when possible, and shown in their raw form otherwise.
+func (t *Transaction) PrettyString() string {
+	var b strings.Builder
+	w := tabwriter.NewWriter(&b, 0, 8, 1, '\t', tabwriter.AlignRight)
+
+	_, _ = fmt.Fprintf(w, "ID\t%s\n", t.ID())
+	_, _ = fmt.Fprintf(w, "Payer\t%s\n", t.Payer)
+	_, _ = fmt.Fprintf(w, "Authorizers\t%s\n", t.Authorizers)
+	_, _ = fmt.Fprintf(w, "Reference Block\t%s\n", t.ReferenceBlockID)
+	_, _ = fmt.Fprintf(w, "Gas Limit\t%d\n", t.GasLimit)
+	_, _ = fmt.Fprintf(w, "\n")
+	_, _ = fmt.Fprintf(w, "Proposal Key:\t\n")
+	_, _ = fmt.Fprintf(w, "    Address\t%s\n", t.ProposalKey.Address)
+	_, _ = fmt.Fprintf(w, "    Index\t%d\n", t.ProposalKey.KeyIndex)
+	_, _ = fmt.Fprintf(w, "    Sequence\t%d\n", t.ProposalKey.SequenceNumber)
+
+	writeSignatures := func(title string, signatures []TransactionSignature) {
+		_, _ = fmt.Fprintf(w, "\n")
+		if len(signatures) == 0 {
+			_, _ = fmt.Fprintf(w, "No %s\t\n", strings.ToLower(title))
+			return
+		}
+
+		for i, sig := range signatures {
+			_, _ = fmt.Fprintf(w, "%s %d:\t\n", title, i)
+			_, _ = fmt.Fprintf(w, "    Address\t%s\n", sig.Address)
+			_, _ = fmt.Fprintf(w, "    Key Index\t%d\n", sig.KeyIndex)
+			_, _ = fmt.Fprintf(w, "    Signature\t%x\n", sig.Signature)
+		}
+	}
+	writeSignatures("Payload Signature", t.PayloadSignatures)
+	writeSignatures("Envelope Signature", t.EnvelopeSignatures)
+
+	_, _ = fmt.Fprintf(w, "\n")
+	if len(t.Arguments) == 0 {
+		_, _ = fmt.Fprintf(w, "No arguments\t\n")
+	} else {
+		_, _ = fmt.Fprintf(w, "Arguments (%d):\t\n", len(t.Arguments))
+		for i, arg := range t.Arguments {
+			value, err := jsoncdc.Decode(nil, arg)
+			if err != nil {
+				_, _ = fmt.Fprintf(w, "    - Argument %d:\t%s\n", i, strings.TrimSpace(string(arg)))
+				continue
+			}
+			_, _ = fmt.Fprintf(w, "    - Argument %d:\t%s\n", i, value)
+		}
+	}
+	_ = w.Flush()
+
+	b.WriteString("\nCode\n\n")
+	b.Write(t.Script)
+	b.WriteString("\n")
+
+	return b.String()
+}
@@ -0,0 +1,173 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2023 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flow
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/onflow/cadence"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testTransaction(t *testing.T) *Transaction {
+	proposer := HexToAddress("01")
+	payer := HexToAddress("02")
+
+	tx := NewTransaction().
+		SetScript([]byte(`transaction(amount: UFix64) { prepare(signer: AuthAccount) {} }`)).
+		SetReferenceBlockID(Identifier{1, 2, 3}).
+		SetProposalKey(proposer, 1, 42).
+		SetPayer(payer).
+		AddAuthorizer(proposer)
+
+	amount, err := cadence.NewUFix64("10.5")
+	require.NoError(t, err)
+	require.NoError(t, tx.AddArgument(amount))
+
+	tx.AddPayloadSignature(proposer, 1, []byte{1, 2, 3})
+	tx.AddEnvelopeSignature(payer, 0, []byte{4, 5, 6})
+
+	return tx
+}
+
+func TestTransaction_JSON(t *testing.T) {
+	tx := testTransaction(t)
+
+	data, err := json.Marshal(tx)
+	require.NoError(t, err)
+
+	var fields map[string]any
+	require.NoError(t, json.Unmarshal(data, &fields))
+	assert.Equal(t, "9999", fields["gas_limit"])
+	assert.Equal(t, "0000000000000002", fields["payer"])
+	assert.Equal(t, map[string]any{
+		"address":         "0000000000000001",
+		"key_index":       "1",
+		"sequence_number": "42",
+	}, fields["proposal_key"])
+	assert.Equal(t, tx.ID().Hex(), fields["id"])
+
+	var decoded Transaction
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, tx, &decoded)
+	assert.Equal(t, tx.Encode(), decoded.Encode())
+}
+
+func TestTransaction_UnmarshalJSON(t *testing.T) {
+	t.Run("plain arguments and numbers", func(t *testing.T) {
+		data := []byte(`{
+			"script": "",
+			"arguments": [{"type": "Int", "value": "1"}],
+			"reference_block_id": "0x0102000000000000000000000000000000000000000000000000000000000000",
+			"gas_limit": 100,
+			"payer": "0x02",
+			"proposal_key": {"address": "0x02", "key_index": 0, "sequence_number": "3"},
+			"authorizers": ["0x02"],
+			"payload_signatures": [],
+			"envelope_signatures": [{"address": "0x02", "key_index": "0", "signature": "AQI="}]
+		}`)
+
+		var tx Transaction
+		require.NoError(t, json.Unmarshal(data, &tx))
+
+		assert.Equal(t, uint64(100), tx.GasLimit)
+		assert.Equal(t, uint64(3), tx.ProposalKey.SequenceNumber)
+		assert.Equal(t, Identifier{1, 2}, tx.ReferenceBlockID)
+
+		arg, err := tx.Argument(0)
+		require.NoError(t, err)
+		assert.Equal(t, cadence.NewInt(1), arg)
+
+		require.Len(t, tx.EnvelopeSignatures, 1)
+		assert.Equal(t, 0, tx.EnvelopeSignatures[0].SignerIndex)
+		assert.Equal(t, []byte{1, 2}, tx.EnvelopeSignatures[0].Signature)
+	})
+
+	t.Run("invalid hex", func(t *testing.T) {
+		valid := map[string]any{
+			"reference_block_id":  Identifier{1}.Hex(),
+			"gas_limit":           "1",
+			"payer":               "02",
+			"proposal_key":        map[string]any{"address": "02", "key_index": "0", "sequence_number": "0"},
+			"authorizers":         []string{"02"},
+			"envelope_signatures": []map[string]any{{"address": "02", "key_index": "0", "signature": "AQI="}},
+		}
+
+		for _, test := range []struct {
+			field, value, message string
+		}{
+			{"reference_block_id", "0x0102", "invalid reference block ID"},
+			{"reference_block_id", Identifier{1}.Hex() + "00", "invalid reference block ID"},
+			{"payer", "0xzz", "invalid payer"},
+			{"payer", "000000000000000002", "invalid payer"},
+			{"proposal_key", "0xzz", "invalid proposal key"},
+			{"authorizers", "0xzz", "invalid authorizer at index 0"},
+			{"envelope_signatures", "0xzz", "invalid envelope signatures"},
+		} {
+			fields := make(map[string]any, len(valid))
+			for k, v := range valid {
+				fields[k] = v
+			}
+			switch test.field {
+			case "proposal_key":
+				fields[test.field] = map[string]any{"address": test.value, "key_index": "0", "sequence_number": "0"}
+			case "authorizers":
+				fields[test.field] = []string{test.value}
+			case "envelope_signatures":
+				fields[test.field] = []map[string]any{{"address": test.value, "key_index": "0", "signature": "AQI="}}
+			default:
+				fields[test.field] = test.value
+			}
+
+			data, err := json.Marshal(fields)
+			require.NoError(t, err)
+
+			var tx Transaction
+			assert.ErrorContains(t, json.Unmarshal(data, &tx), test.message, test.value)
+		}
+
+		data, err := json.Marshal(valid)
+		require.NoError(t, err)
+		var tx Transaction
+		require.NoError(t, json.Unmarshal(data, &tx))
+		assert.Equal(t, HexToAddress("02"), tx.Payer)
+	})
+
+	t.Run("invalid signature", func(t *testing.T) {
+		data := []byte(`{"reference_block_id": "", "gas_limit": "1", "proposal_key": {"key_index": "0", "sequence_number": "0"},
+			"payload_signatures": [{"address": "01", "key_index": "0", "signature": "%%"}]}`)
+
+		var tx Transaction
+		err := json.Unmarshal(data, &tx)
+		assert.ErrorContains(t, err, "invalid payload signatures")
+	})
+}
+
+func TestTransaction_PrettyString(t *testing.T) {
+	tx := testTransaction(t)
+
+	s := tx.PrettyString()
+	assert.Contains(t, s, "0000000000000002")
+	assert.Contains(t, s, "10.50000000")
+	assert.Contains(t, s, "Payload Signature 0:")
+	assert.Contains(t, s, "Envelope Signature 0:")
+	assert.Contains(t, s, "transaction(amount: UFix64)")
+}
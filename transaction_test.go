@@ -0,0 +1,147 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2023 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flow
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/onflow/cadence"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// vectorTransaction is the transaction used by the encoding tests of the JavaScript SDK,
+// with the arguments encoded as JSON.stringify does, without a trailing newline.
+func vectorTransaction(arguments ...[]byte) *Transaction {
+	tx := NewTransaction().
+		SetScript([]byte(`transaction { execute { log("Hello, World!") } }`)).
+		SetReferenceBlockID(HexToID("f0e4c2f76c58916ec258f246851bea091d14d4247a2fc3e18694461b1816e13b")).
+		SetGasLimit(42).
+		SetProposalKey(HexToAddress("01"), 4, 10).
+		SetPayer(HexToAddress("01")).
+		AddAuthorizer(HexToAddress("01"))
+	tx.Arguments = arguments
+	return tx
+}
+
+func mustDecodeHex(t *testing.T, s string) []byte {
+	b, err := hex.DecodeString(s)
+	require.NoError(t, err)
+	return b
+}
+
+func TestTransaction_EncodingVectors(t *testing.T) {
+	const signature = "f7225388c1d69d57e6251c9fda50cbbf9e05131e5adb81e5aa0422402f048162"
+
+	tests := []struct {
+		name      string
+		arguments [][]byte
+		payload   string
+		envelope  string
+		encoded   string
+		id        string
+	}{
+		{
+			name:     "no arguments",
+			payload:  "f872b07472616e73616374696f6e207b2065786563757465207b206c6f67282248656c6c6f2c20576f726c64212229207d207dc0a0f0e4c2f76c58916ec258f246851bea091d14d4247a2fc3e18694461b1816e13b2a880000000000000001040a880000000000000001c9880000000000000001",
+			envelope: "f899f872b07472616e73616374696f6e207b2065786563757465207b206c6f67282248656c6c6f2c20576f726c64212229207d207dc0a0f0e4c2f76c58916ec258f246851bea091d14d4247a2fc3e18694461b1816e13b2a880000000000000001040a880000000000000001c9880000000000000001e4e38004a0f7225388c1d69d57e6251c9fda50cbbf9e05131e5adb81e5aa0422402f048162",
+		},
+		{
+			name: "arguments",
+			arguments: [][]byte{
+				[]byte(`{"type":"String","value":"foo"}`),
+				[]byte(`{"type":"Int","value":"42"}`),
+			},
+			payload:  "f8afb07472616e73616374696f6e207b2065786563757465207b206c6f67282248656c6c6f2c20576f726c64212229207d207df83c9f7b2274797065223a22537472696e67222c2276616c7565223a22666f6f227d9b7b2274797065223a22496e74222c2276616c7565223a223432227da0f0e4c2f76c58916ec258f246851bea091d14d4247a2fc3e18694461b1816e13b2a880000000000000001040a880000000000000001c9880000000000000001",
+			envelope: "f8d6f8afb07472616e73616374696f6e207b2065786563757465207b206c6f67282248656c6c6f2c20576f726c64212229207d207df83c9f7b2274797065223a22537472696e67222c2276616c7565223a22666f6f227d9b7b2274797065223a22496e74222c2276616c7565223a223432227da0f0e4c2f76c58916ec258f246851bea091d14d4247a2fc3e18694461b1816e13b2a880000000000000001040a880000000000000001c9880000000000000001e4e38004a0f7225388c1d69d57e6251c9fda50cbbf9e05131e5adb81e5aa0422402f048162",
+			encoded:  "f8fbf8afb07472616e73616374696f6e207b2065786563757465207b206c6f67282248656c6c6f2c20576f726c64212229207d207df83c9f7b2274797065223a22537472696e67222c2276616c7565223a22666f6f227d9b7b2274797065223a22496e74222c2276616c7565223a223432227da0f0e4c2f76c58916ec258f246851bea091d14d4247a2fc3e18694461b1816e13b2a880000000000000001040a880000000000000001c9880000000000000001e4e38004a0f7225388c1d69d57e6251c9fda50cbbf9e05131e5adb81e5aa0422402f048162e4e38004a0f7225388c1d69d57e6251c9fda50cbbf9e05131e5adb81e5aa0422402f048162",
+			id:       "396de97014bfe02dd32025217b595b154e22c48ff9eb0e68a4242184f83810b2",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// the Go JSON-CDC encoder terminates arguments with a newline,
+			// which must not change the signed messages
+			withNewlines := make([][]byte, len(test.arguments))
+			for i, arg := range test.arguments {
+				withNewlines[i] = append(append([]byte{}, arg...), '\n')
+			}
+
+			for _, tx := range []*Transaction{vectorTransaction(test.arguments...), vectorTransaction(withNewlines...)} {
+				assert.Equal(t, test.payload, hex.EncodeToString(tx.PayloadMessage()))
+
+				tx.AddPayloadSignature(HexToAddress("01"), 4, mustDecodeHex(t, signature))
+				assert.Equal(t, test.envelope, hex.EncodeToString(tx.EnvelopeMessage()))
+
+				if test.encoded != "" {
+					tx.AddEnvelopeSignature(HexToAddress("01"), 4, mustDecodeHex(t, signature))
+					assert.Equal(t, test.encoded, hex.EncodeToString(tx.Encode()))
+					assert.Equal(t, test.id, tx.ID().Hex())
+				}
+			}
+		})
+	}
+}
+
+func TestTransaction_CanonicalArguments(t *testing.T) {
+	t.Run("does not mutate", func(t *testing.T) {
+		arg := []byte("{\"type\":\"Int\",\"value\":\"1\"}\n")
+		tx := vectorTransaction(arg)
+
+		tx.PayloadMessage()
+		tx.EnvelopeMessage()
+		tx.Encode()
+
+		assert.Equal(t, [][]byte{arg}, tx.Arguments)
+		assert.Equal(t, [][]byte{arg[:len(arg)-1]}, tx.CanonicalArguments())
+	})
+
+	t.Run("empty argument", func(t *testing.T) {
+		tx := vectorTransaction([]byte{})
+		assert.NotPanics(t, func() { tx.PayloadMessage() })
+	})
+
+	t.Run("added arguments are canonical", func(t *testing.T) {
+		tx := vectorTransaction()
+		require.NoError(t, tx.AddArgument(cadence.String("foo")))
+		tx.AddRawArgument([]byte("{\"type\":\"Int\",\"value\":\"42\"}\n"))
+
+		for _, arg := range tx.Arguments {
+			assert.NotEqual(t, byte('\n'), arg[len(arg)-1])
+		}
+	})
+
+	t.Run("decoding", func(t *testing.T) {
+		tx := vectorTransaction([]byte("{\"type\":\"Int\",\"value\":\"1\"}\n"))
+
+		decoded, err := DecodeTransaction(tx.Encode())
+		require.NoError(t, err)
+		assert.Equal(t, tx.CanonicalArguments(), decoded.Arguments)
+		assert.Equal(t, tx.Encode(), decoded.Encode())
+	})
+}
+
+func TestNormalizeArgument(t *testing.T) {
+	assert.Equal(t, []byte(`{}`), NormalizeArgument([]byte("{}\n")))
+	assert.Equal(t, []byte(`{}`), NormalizeArgument([]byte(`{}`)))
+	assert.Equal(t, []byte("{}\n"), NormalizeArgument([]byte("{}\n\n")))
+	assert.Empty(t, NormalizeArgument(nil))
+}
@@ -0,0 +1,273 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2023 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flow
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	jsoncdc "github.com/onflow/cadence/encoding/json"
+)
+
+const (
+	// MaxTransactionGasLimit is the maximum gas limit accepted by the network.
+	MaxTransactionGasLimit = 9999
+	// MaxTransactionByteSize is the maximum encoded size of a transaction accepted by the network.
+	MaxTransactionByteSize = 1_500_000
+)
+
+// InvalidAddressError indicates that an address of the transaction is not valid for the chain.
+type InvalidAddressError struct {
+	// Field is the transaction field containing the address, e.g. "payer" or "authorizers[1]",
+	// empty if the address is not part of a transaction.
+	Field   string
+	Address Address
+	Chain   ChainID
+}
+
+func (e InvalidAddressError) Error() string {
+	if e.Field == "" {
+		return fmt.Sprintf("address %s is not valid for chain %s", e.Address, e.Chain)
+	}
+	return fmt.Sprintf("%s address %s is not valid for chain %s", e.Field, e.Address, e.Chain)
+}
+
+// InvalidScriptError indicates that the transaction script is empty or not valid UTF-8.
+type InvalidScriptError struct {
+	Reason string
+}
+
+func (e InvalidScriptError) Error() string {
+	return fmt.Sprintf("invalid script: %s", e.Reason)
+}
+
+// InvalidArgumentError indicates that a transaction argument is not valid JSON-CDC.
+type InvalidArgumentError struct {
+	Index int
+	Err   error
+}
+
+func (e InvalidArgumentError) Error() string {
+	return fmt.Sprintf("argument %d is not valid JSON-CDC: %v", e.Index, e.Err)
+}
+
+func (e InvalidArgumentError) Unwrap() error {
+	return e.Err
+}
+
+// InvalidGasLimitError indicates that the gas limit is zero or above MaxTransactionGasLimit.
+type InvalidGasLimitError struct {
+	GasLimit uint64
+}
+
+func (e InvalidGasLimitError) Error() string {
+	return fmt.Sprintf("gas limit %d is not between 1 and %d", e.GasLimit, MaxTransactionGasLimit)
+}
+
+// MissingReferenceBlockError indicates that the reference block ID is not set.
+type MissingReferenceBlockError struct{}
+
+func (e MissingReferenceBlockError) Error() string {
+	return "reference block ID is not set"
+}
+
+// UnknownSignerError indicates a signature from an account which is not a signer of the transaction.
+type UnknownSignerError struct {
+	Address  Address
+	KeyIndex int
+	Envelope bool
+}
+
+func (e UnknownSignerError) Error() string {
+	return fmt.Sprintf("%s signature of account %s is not from a transaction signer", signatureKind(e.Envelope), e.Address)
+}
+
+// DuplicateSignatureError indicates that an account key signed the transaction more than once.
+type DuplicateSignatureError struct {
+	Address  Address
+	KeyIndex int
+	Envelope bool
+}
+
+func (e DuplicateSignatureError) Error() string {
+	return fmt.Sprintf("duplicate %s signature of account %s with key %d", signatureKind(e.Envelope), e.Address, e.KeyIndex)
+}
+
+// InvalidEnvelopeSignerError indicates that the envelope is signed by an account other than the payer.
+type InvalidEnvelopeSignerError struct {
+	Address Address
+	Payer   Address
+}
+
+func (e InvalidEnvelopeSignerError) Error() string {
+	return fmt.Sprintf("envelope is signed by %s which is not the payer %s", e.Address, e.Payer)
+}
+
+// TransactionSizeError indicates that the encoded transaction is larger than MaxTransactionByteSize.
+type TransactionSizeError struct {
+	Size int
+}
+
+func (e TransactionSizeError) Error() string {
+	return fmt.Sprintf("transaction size of %d bytes exceeds the limit of %d bytes", e.Size, MaxTransactionByteSize)
+}
+
+// TransactionValidationError contains all the violations found when validating a transaction.
+//
+// Each violation is one of the typed errors above and can be matched with errors.As.
+type TransactionValidationError struct {
+	Errors []error
+}
+
+func (e *TransactionValidationError) Error() string {
+	messages := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		messages[i] = err.Error()
+	}
+	return fmt.Sprintf("invalid transaction: %s", strings.Join(messages, "; "))
+}
+
+func (e *TransactionValidationError) Unwrap() []error {
+	return e.Errors
+}
+
+// Is reports whether any of the violations matches target.
+//
+// Is and As are implemented in addition to Unwrap, as errors.Is and errors.As only follow
+// multiple wrapped errors from Go 1.20.
+func (e *TransactionValidationError) Is(target error) bool {
+	for _, err := range e.Errors {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// As finds the first violation which matches target.
+func (e *TransactionValidationError) As(target any) bool {
+	for _, err := range e.Errors {
+		if errors.As(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate performs static checks of the transaction against the rules enforced by the network
+// for the given chain, without contacting an access node.
+//
+// A *TransactionValidationError listing every violation is returned if the transaction is invalid,
+// and ErrUnknownChain if no address scheme is registered for the chain ID.
+func (t *Transaction) Validate(chain ChainID) error {
+	scheme, err := chain.AddressScheme()
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+
+	checkAddress := func(field string, address Address) {
+		if !scheme.isValid(address) {
+			errs = append(errs, InvalidAddressError{Field: field, Address: address, Chain: chain})
+		}
+	}
+
+	checkAddress("proposer", t.ProposalKey.Address)
+	checkAddress("payer", t.Payer)
+	for i, authorizer := range t.Authorizers {
+		checkAddress(fmt.Sprintf("authorizers[%d]", i), authorizer)
+	}
+
+	if len(t.Script) == 0 {
+		errs = append(errs, InvalidScriptError{Reason: "script is empty"})
+	} else if !utf8.Valid(t.Script) {
+		errs = append(errs, InvalidScriptError{Reason: "script is not valid UTF-8"})
+	}
+
+	for i, arg := range t.Arguments {
+		if _, err := jsoncdc.Decode(nil, arg); err != nil {
+			errs = append(errs, InvalidArgumentError{Index: i, Err: err})
+		}
+	}
+
+	if t.GasLimit == 0 || t.GasLimit > MaxTransactionGasLimit {
+		errs = append(errs, InvalidGasLimitError{GasLimit: t.GasLimit})
+	}
+
+	if t.ReferenceBlockID == EmptyID {
+		errs = append(errs, MissingReferenceBlockError{})
+	}
+
+	errs = append(errs, validateSignatures(t.PayloadSignatures, false)...)
+	errs = append(errs, validateSignatures(t.EnvelopeSignatures, true)...)
+
+	for _, sig := range t.EnvelopeSignatures {
+		if sig.Address != t.Payer {
+			errs = append(errs, InvalidEnvelopeSignerError{Address: sig.Address, Payer: t.Payer})
+		}
+	}
+
+	// the size is only checked for otherwise valid transactions, as
+	// encoding requires valid arguments and signer indices
+	if len(errs) == 0 {
+		if size := len(t.Encode()); size > MaxTransactionByteSize {
+			errs = append(errs, TransactionSizeError{Size: size})
+		}
+	}
+
+	if len(errs) > 0 {
+		return &TransactionValidationError{Errors: errs}
+	}
+
+	return nil
+}
+
+func validateSignatures(signatures []TransactionSignature, envelope bool) []error {
+	var errs []error
+
+	type signatureKey struct {
+		address  Address
+		keyIndex int
+	}
+	seen := make(map[signatureKey]struct{})
+
+	for _, sig := range signatures {
+		if sig.SignerIndex < 0 {
+			errs = append(errs, UnknownSignerError{Address: sig.Address, KeyIndex: sig.KeyIndex, Envelope: envelope})
+		}
+
+		key := signatureKey{address: sig.Address, keyIndex: sig.KeyIndex}
+		if _, ok := seen[key]; ok {
+			errs = append(errs, DuplicateSignatureError{Address: sig.Address, KeyIndex: sig.KeyIndex, Envelope: envelope})
+		}
+		seen[key] = struct{}{}
+	}
+
+	return errs
+}
+
+func signatureKind(envelope bool) string {
+	if envelope {
+		return "envelope"
+	}
+	return "payload"
+}
@@ -0,0 +1,122 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright 2023 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flow
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/onflow/cadence"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func validTestTransaction(t *testing.T) *Transaction {
+	gen := NewAddressGenerator(Emulator)
+	proposer := gen.NextAddress()
+	payer := gen.NextAddress()
+
+	tx := NewTransaction().
+		SetScript([]byte(`transaction(n: Int) { prepare(signer: AuthAccount) {} }`)).
+		SetReferenceBlockID(Identifier{1}).
+		SetProposalKey(proposer, 0, 1).
+		SetPayer(payer).
+		AddAuthorizer(proposer)
+	require.NoError(t, tx.AddArgument(cadence.NewInt(42)))
+
+	tx.AddPayloadSignature(proposer, 0, []byte{1})
+	tx.AddEnvelopeSignature(payer, 0, []byte{2})
+
+	return tx
+}
+
+func TestTransaction_Validate(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		tx := validTestTransaction(t)
+		assert.NoError(t, tx.Validate(Emulator))
+	})
+
+	t.Run("wrong chain", func(t *testing.T) {
+		tx := validTestTransaction(t)
+
+		err := tx.Validate(Mainnet)
+		var addressErr InvalidAddressError
+		require.ErrorAs(t, err, &addressErr)
+		assert.Equal(t, "proposer", addressErr.Field)
+
+		var validationErr *TransactionValidationError
+		require.ErrorAs(t, err, &validationErr)
+		assert.Len(t, validationErr.Errors, 3)
+
+		// matching does not depend on errors.As following multiple wrapped errors
+		var direct InvalidAddressError
+		require.True(t, validationErr.As(&direct))
+		assert.Equal(t, addressErr, direct)
+		assert.True(t, validationErr.Is(addressErr))
+		assert.False(t, validationErr.Is(MissingReferenceBlockError{}))
+	})
+
+	t.Run("all violations", func(t *testing.T) {
+		tx := validTestTransaction(t)
+		tx.Script = []byte{0xff}
+		tx.Arguments = append(tx.Arguments, []byte("not json"))
+		tx.GasLimit = 0
+		tx.ReferenceBlockID = EmptyID
+		tx.AddPayloadSignature(tx.ProposalKey.Address, 0, []byte{3})
+		tx.AddPayloadSignature(HexToAddress("ff"), 0, []byte{4})
+		tx.AddEnvelopeSignature(tx.ProposalKey.Address, 1, []byte{5})
+
+		err := tx.Validate(Emulator)
+		var validationErr *TransactionValidationError
+		require.ErrorAs(t, err, &validationErr)
+
+		assert.Equal(t, []error{
+			InvalidScriptError{Reason: "script is not valid UTF-8"},
+			validationErr.Errors[1],
+			InvalidGasLimitError{GasLimit: 0},
+			MissingReferenceBlockError{},
+			UnknownSignerError{Address: HexToAddress("ff"), KeyIndex: 0},
+			DuplicateSignatureError{Address: tx.ProposalKey.Address, KeyIndex: 0},
+			InvalidEnvelopeSignerError{Address: tx.ProposalKey.Address, Payer: tx.Payer},
+		}, validationErr.Errors)
+
+		var argErr InvalidArgumentError
+		require.True(t, errors.As(validationErr.Errors[1], &argErr))
+		assert.Equal(t, 1, argErr.Index)
+	})
+
+	t.Run("empty script", func(t *testing.T) {
+		tx := validTestTransaction(t)
+		tx.Script = nil
+
+		var scriptErr InvalidScriptError
+		require.ErrorAs(t, tx.Validate(Emulator), &scriptErr)
+		assert.Equal(t, "script is empty", scriptErr.Reason)
+	})
+
+	t.Run("too large", func(t *testing.T) {
+		tx := validTestTransaction(t)
+		tx.Script = []byte(strings.Repeat("a", MaxTransactionByteSize))
+
+		var sizeErr TransactionSizeError
+		require.ErrorAs(t, tx.Validate(Emulator), &sizeErr)
+		assert.Greater(t, sizeErr.Size, MaxTransactionByteSize)
+	})
+}